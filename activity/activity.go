@@ -0,0 +1,329 @@
+// Package activity scores forecast hours from 0 to 100 for outdoor
+// activities and finds the best windows to do them.
+package activity
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
+)
+
+// DefaultMinScore is the lowest score an hour needs to be part of a
+// suggested window
+const DefaultMinScore = 70
+
+// Factors are the names of the weather factors a profile weighs
+var Factors = []string{"temp", "feels_like", "wind", "rain", "uv", "daylight"}
+
+// tolerances are how far outside its preferred range a factor may go
+// before it scores nothing: degrees, km/h, percentage points or UV index
+var tolerances = map[string]float64{
+	"temp":       10,
+	"feels_like": 10,
+	"wind":       20,
+	"rain":       40,
+	"uv":         4,
+}
+
+// Range is a preferred span of values, inclusive
+type Range struct {
+	Min, Max float64
+}
+
+// score is 1 within the range, falling to 0 at tolerance outside it
+func (r Range) score(v, tolerance float64) float64 {
+	switch {
+	case v < r.Min:
+		return math.Max(0, 1-(r.Min-v)/tolerance)
+	case v > r.Max:
+		return math.Max(0, 1-(v-r.Max)/tolerance)
+	}
+	return 1
+}
+
+// Profile describes the weather an activity prefers
+type Profile struct {
+	Name      string
+	Temp      Range // air temperature, °C
+	FeelsLike Range // apparent temperature, °C
+	Wind      Range // sustained wind, km/h
+	Rain      Range // chance of rain, %
+	UV        Range // UV index
+
+	// Daylight limits the activity to between sunrise and sunset, and
+	// GoldenHour further prefers the hour after sunrise and before sunset
+	Daylight   bool
+	GoldenHour bool
+
+	// Weights sets how much each of Factors counts; a zero weight ignores
+	// the factor
+	Weights map[string]float64
+}
+
+// profiles are the built-in activities by name
+var profiles = map[string]Profile{
+	"running": {
+		Temp: Range{5, 18}, FeelsLike: Range{5, 20}, Wind: Range{0, 20}, Rain: Range{0, 20}, UV: Range{0, 5},
+		Daylight: true,
+		Weights:  map[string]float64{"temp": 2, "feels_like": 2, "wind": 1, "rain": 3, "uv": 1, "daylight": 1},
+	},
+	"cycling": {
+		Temp: Range{10, 24}, FeelsLike: Range{10, 25}, Wind: Range{0, 15}, Rain: Range{0, 10}, UV: Range{0, 6},
+		Daylight: true,
+		Weights:  map[string]float64{"temp": 2, "feels_like": 1, "wind": 3, "rain": 3, "uv": 1, "daylight": 2},
+	},
+	"hiking": {
+		Temp: Range{8, 22}, FeelsLike: Range{8, 24}, Wind: Range{0, 25}, Rain: Range{0, 20}, UV: Range{0, 6},
+		Daylight: true,
+		Weights:  map[string]float64{"temp": 2, "feels_like": 1, "wind": 1, "rain": 3, "uv": 2, "daylight": 3},
+	},
+	"photography": {
+		Temp: Range{-5, 30}, FeelsLike: Range{-5, 32}, Wind: Range{0, 30}, Rain: Range{0, 30}, UV: Range{0, 11},
+		Daylight: true, GoldenHour: true,
+		Weights: map[string]float64{"temp": 0.5, "feels_like": 0.5, "wind": 1, "rain": 3, "daylight": 3},
+	},
+	"laundry": {
+		Temp: Range{15, 35}, FeelsLike: Range{0, 50}, Wind: Range{8, 35}, Rain: Range{0, 5}, UV: Range{3, 11},
+		Daylight: true,
+		Weights:  map[string]float64{"temp": 2, "wind": 2, "rain": 4, "uv": 1, "daylight": 2},
+	},
+	"bbq": {
+		Temp: Range{18, 30}, FeelsLike: Range{18, 30}, Wind: Range{0, 15}, Rain: Range{0, 10}, UV: Range{0, 7},
+		Weights: map[string]float64{"temp": 2, "feels_like": 2, "wind": 2, "rain": 4, "uv": 1},
+	},
+}
+
+// Names returns the names of the built-in activities, sorted
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns a copy of a built-in activity's profile
+func Lookup(name string) (*Profile, error) {
+	p, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown activity %q (choose from %s, or define one under activities)", name, strings.Join(Names(), ", "))
+	}
+	p.Name = strings.ToLower(name)
+	weights := make(map[string]float64, len(p.Weights))
+	for factor, w := range p.Weights {
+		weights[factor] = w
+	}
+	p.Weights = weights
+	return &p, nil
+}
+
+// Validate checks that every range runs upwards, and that the weights name
+// known factors, are not negative and do not all vanish
+func (p *Profile) Validate() error {
+	ranges := map[string]Range{"temp": p.Temp, "feels_like": p.FeelsLike, "wind": p.Wind, "rain": p.Rain, "uv": p.UV}
+	for _, factor := range Factors {
+		if r, ok := ranges[factor]; ok && r.Min > r.Max {
+			return fmt.Errorf("activity %s: %s range [%g, %g] runs backwards", p.Name, factor, r.Min, r.Max)
+		}
+	}
+
+	total := 0.0
+	for factor, w := range p.Weights {
+		if !isFactor(factor) {
+			return fmt.Errorf("activity %s: unknown factor %q (choose from %s)", p.Name, factor, strings.Join(Factors, ", "))
+		}
+		if w < 0 {
+			return fmt.Errorf("activity %s: weight of %s is negative", p.Name, factor)
+		}
+		total += w
+	}
+	if total == 0 {
+		return fmt.Errorf("activity %s: every weight is zero", p.Name)
+	}
+	return nil
+}
+
+// isFactor reports whether name is one of Factors
+func isFactor(name string) bool {
+	for _, factor := range Factors {
+		if factor == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Score rates an hour from 0 to 100. Each weighted factor scores from 0 to
+// 1 and the weighted average is scaled by the worst of them, from half for
+// a factor that rules the hour out to all of it, so that a downpour cannot
+// be outweighed by perfect temperatures. Factors named in missing, such as
+// a UV index the provider does not report, are left out.
+func (p *Profile) Score(hour model.Hour, astro model.Astro, missing ...string) int {
+	light := p.light(hour, astro)
+	factors := map[string]float64{
+		"temp":       p.Temp.score(hour.TempC, tolerances["temp"]),
+		"feels_like": p.FeelsLike.score(hour.FeelsLikeC, tolerances["feels_like"]),
+		"wind":       p.Wind.score(hour.WindKph, tolerances["wind"]),
+		"rain":       p.Rain.score(float64(hour.ChanceOfRain), tolerances["rain"]),
+		"uv":         p.UV.score(hour.UV, tolerances["uv"]),
+		"daylight":   light,
+	}
+
+	sum, total, worst := 0.0, 0.0, 1.0
+	for factor, w := range p.Weights {
+		s, ok := factors[factor]
+		if !ok || w <= 0 || (factor == "daylight" && !p.Daylight) || slices.Contains(missing, factor) {
+			continue
+		}
+		if math.IsNaN(s) {
+			s = 0
+		}
+		sum += w * s
+		total += w
+		worst = math.Min(worst, s)
+	}
+	if total == 0 {
+		return 0
+	}
+	return int(math.Round(100 * sum / total * (0.5 + 0.5*worst)))
+}
+
+// light scores an hour's daylight: 1 between sunrise and sunset, or with
+// GoldenHour 1 in the hour after sunrise and before sunset and 0.5 in the
+// rest of the day. An hour counts as lit if its middle is. Without usable
+// sunrise and sunset times the provider's day flag decides.
+func (p *Profile) light(hour model.Hour, astro model.Astro) float64 {
+	t, err := time.Parse("2006-01-02 15:04", hour.Time)
+	if err != nil {
+		return float64(hour.IsDay)
+	}
+	date := t.Format("2006-01-02")
+	sunrise, err1 := time.Parse("2006-01-02 03:04 PM", date+" "+astro.Sunrise)
+	sunset, err2 := time.Parse("2006-01-02 03:04 PM", date+" "+astro.Sunset)
+	if err1 != nil || err2 != nil {
+		return float64(hour.IsDay)
+	}
+
+	middle := t.Add(30 * time.Minute)
+	switch {
+	case middle.Before(sunrise) || middle.After(sunset):
+		return 0
+	case !p.GoldenHour:
+		return 1
+	case middle.Before(sunrise.Add(time.Hour)) || middle.After(sunset.Add(-time.Hour)):
+		return 1
+	}
+	return 0.5
+}
+
+// HourScore is a forecast hour and its score
+type HourScore struct {
+	Hour  model.Hour
+	Score int
+}
+
+// Day is a forecast day's scored hours
+type Day struct {
+	Date  string
+	Hours []HourScore
+}
+
+// missingFactors returns the factors the provider of data does not report
+func missingFactors(data *model.WeatherData) []string {
+	if !api.SuppliesUV(data.Provider) {
+		return []string{"uv"}
+	}
+	return nil
+}
+
+// Plan scores every forecast hour from the location's local time onwards,
+// leaving out factors the provider does not report
+func (p *Profile) Plan(data *model.WeatherData) []Day {
+	now, nowErr := time.Parse("2006-01-02 15:04", data.Location.Localtime)
+	now = now.Truncate(time.Hour)
+	missing := missingFactors(data)
+
+	var days []Day
+	for _, forecast := range data.Forecast.ForecastDay {
+		day := Day{Date: forecast.Date}
+		for _, hour := range forecast.Hour {
+			t, err := time.Parse("2006-01-02 15:04", hour.Time)
+			if nowErr == nil && err == nil && t.Before(now) {
+				continue
+			}
+			day.Hours = append(day.Hours, HourScore{Hour: hour, Score: p.Score(hour, forecast.Astro, missing...)})
+		}
+		if len(day.Hours) > 0 {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// Window is a run of consecutive hours on one day that all score at least
+// the minimum
+type Window struct {
+	Date  string
+	Hours []HourScore
+}
+
+// Score is the window's average score, rounded
+func (w Window) Score() int {
+	sum := 0
+	for _, h := range w.Hours {
+		sum += h.Score
+	}
+	return int(math.Round(float64(sum) / float64(len(w.Hours))))
+}
+
+// BestWindows returns up to n windows of hours scoring at least minScore,
+// best first: highest average score, then longest, then earliest
+func BestWindows(days []Day, minScore, n int) []Window {
+	var windows []Window
+	for _, day := range days {
+		var current []HourScore
+		flush := func() {
+			if len(current) > 0 {
+				windows = append(windows, Window{Date: day.Date, Hours: current})
+				current = nil
+			}
+		}
+		for i, h := range day.Hours {
+			// Hours missing from the forecast break a window
+			if i > 0 && !consecutive(day.Hours[i-1].Hour, h.Hour) {
+				flush()
+			}
+			if h.Score < minScore {
+				flush()
+				continue
+			}
+			current = append(current, h)
+		}
+		flush()
+	}
+
+	sort.SliceStable(windows, func(i, j int) bool {
+		if a, b := windows[i].Score(), windows[j].Score(); a != b {
+			return a > b
+		}
+		return len(windows[i].Hours) > len(windows[j].Hours)
+	})
+	if len(windows) > n {
+		windows = windows[:n]
+	}
+	return windows
+}
+
+// consecutive reports whether b is the hour after a
+func consecutive(a, b model.Hour) bool {
+	ta, errA := time.Parse("2006-01-02 15:04", a.Time)
+	tb, errB := time.Parse("2006-01-02 15:04", b.Time)
+	return errA == nil && errB == nil && tb.Sub(ta) == time.Hour
+}
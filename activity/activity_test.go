@@ -0,0 +1,150 @@
+package activity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
+)
+
+var astro = model.Astro{Sunrise: "07:00 AM", Sunset: "06:00 PM"}
+
+// hour returns a mild, dry, calm hour on Oct 17 at h o'clock
+func hour(h int) model.Hour {
+	return model.Hour{
+		Time:       fmt.Sprintf("2025-10-17 %02d:00", h),
+		TempC:      12,
+		FeelsLikeC: 12,
+		WindKph:    5,
+		UV:         2,
+		IsDay:      1,
+	}
+}
+
+func TestScore(t *testing.T) {
+	running, err := Lookup("running")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wet := hour(12)
+	wet.ChanceOfRain = 90
+	hot := hour(12)
+	hot.TempC, hot.FeelsLikeC = 24, 25
+
+	tests := []struct {
+		name     string
+		hour     model.Hour
+		min, max int
+	}{
+		{"ideal", hour(12), 100, 100},
+		{"night", hour(22), 0, 50},
+		{"downpour", wet, 0, 50},
+		{"too warm", hot, 30, 80},
+	}
+	for _, tt := range tests {
+		if got := running.Score(tt.hour, astro); got < tt.min || got > tt.max {
+			t.Errorf("%s: score = %d, want %d to %d", tt.name, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestScoreWithoutUV(t *testing.T) {
+	laundry, err := Lookup("laundry")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A warm, breezy, dry afternoon as OpenWeatherMap reports it, with no
+	// UV index
+	owm := hour(13)
+	owm.TempC, owm.FeelsLikeC, owm.WindKph, owm.UV = 24, 24, 15, 0
+	if got := laundry.Score(owm, astro, "uv"); got != 100 {
+		t.Errorf("score without UV = %d, want 100", got)
+	}
+	if got := laundry.Score(owm, astro); got >= DefaultMinScore {
+		t.Errorf("score counting UV 0 = %d, want below %d", got, DefaultMinScore)
+	}
+
+	data := &model.WeatherData{
+		Provider: api.ProviderOpenWeatherMap,
+		Forecast: model.Forecast{ForecastDay: []model.ForecastDay{
+			{Date: "2025-10-17", Astro: astro, Hour: []model.Hour{owm}},
+		}},
+	}
+	days := laundry.Plan(data)
+	if len(days) != 1 || days[0].Hours[0].Score != 100 {
+		t.Errorf("Plan for OpenWeatherMap = %+v, want the hour scoring 100", days)
+	}
+}
+
+func TestGoldenHour(t *testing.T) {
+	photography, err := Lookup("photography")
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden, midday := photography.Score(hour(7), astro), photography.Score(hour(12), astro)
+	if golden != 100 || midday >= golden {
+		t.Errorf("golden hour scores %d and midday %d, want 100 and less", golden, midday)
+	}
+	// Without sunrise and sunset the provider's day flag decides
+	if got := photography.light(hour(12), model.Astro{}); got != 1 {
+		t.Errorf("light without astro = %v, want 1", got)
+	}
+}
+
+func TestBestWindows(t *testing.T) {
+	scores := []int{40, 80, 90, 75, 20, 95, 95, 50}
+	day := Day{Date: "2025-10-17"}
+	for i, s := range scores {
+		day.Hours = append(day.Hours, HourScore{Hour: hour(8 + i), Score: s})
+	}
+
+	windows := BestWindows([]Day{day}, 70, 3)
+	if len(windows) != 2 {
+		t.Fatalf("got %d windows, want 2", len(windows))
+	}
+	if got := windows[0].Hours[0].Hour.Time; got != "2025-10-17 13:00" || windows[0].Score() != 95 {
+		t.Errorf("best window starts %s scoring %d, want 13:00 scoring 95", got, windows[0].Score())
+	}
+	if len(windows[1].Hours) != 3 || windows[1].Score() != 82 {
+		t.Errorf("second window is %d hours scoring %d, want 3 scoring 82", len(windows[1].Hours), windows[1].Score())
+	}
+
+	if got := BestWindows([]Day{day}, 70, 1); len(got) != 1 {
+		t.Errorf("limited to 1, got %d windows", len(got))
+	}
+	if got := BestWindows([]Day{day}, 99, 3); len(got) != 0 {
+		t.Errorf("no hour reaches 99, got %d windows", len(got))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Profile)
+	}{
+		{"backwards range", func(p *Profile) { p.Temp = Range{20, 10} }},
+		{"unknown factor", func(p *Profile) { p.Weights["humidity"] = 1 }},
+		{"negative weight", func(p *Profile) { p.Weights["rain"] = -1 }},
+		{"no weights", func(p *Profile) { p.Weights = map[string]float64{} }},
+	}
+	for _, tt := range tests {
+		p, err := Lookup("hiking")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Validate(); err != nil {
+			t.Fatalf("built-in hiking: %v", err)
+		}
+		tt.modify(p)
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: want an error", tt.name)
+		}
+	}
+
+	if _, err := Lookup("knitting"); err == nil {
+		t.Error("unknown activity: want an error")
+	}
+}
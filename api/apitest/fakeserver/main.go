@@ -0,0 +1,22 @@
+// Command fakeserver serves the apitest fixtures on a fixed address so the
+// CLI can be run without network access or a real API key:
+//
+//	go run ./api/apitest/fakeserver -addr localhost:8089
+//	ILLAPACA_API_KEY=test-key illapaca --provider-url http://localhost:8089 current London
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/biferdou/illapaca/api/apitest"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8089", "address to listen on")
+	flag.Parse()
+
+	log.Printf("fake provider server on http://%s (API key %q)", *addr, apitest.APIKey)
+	log.Fatal(http.ListenAndServe(*addr, apitest.Handler()))
+}
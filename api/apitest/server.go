@@ -0,0 +1,162 @@
+// Package apitest provides a fake WeatherAPI.com and OpenWeatherMap server
+// backed by JSON fixtures in the providers' response formats, so clients
+// and the CLI can be exercised offline.
+package apitest
+
+import (
+	"embed"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+)
+
+// APIKey is the only key the fake server accepts
+const APIKey = "test-key"
+
+// Locations with canned responses. Any other location is "not found".
+const (
+	// LocationOK returns a full three-day London forecast
+	LocationOK = "London"
+
+	// LocationEmpty returns current conditions with no forecast days
+	LocationEmpty = "Empty"
+
+	// LocationMalformed returns a truncated JSON body
+	LocationMalformed = "Malformed"
+)
+
+//go:embed testdata/*.json
+var fixtures embed.FS
+
+// Fixture returns the raw contents of a fixture file
+func Fixture(name string) []byte {
+	data, err := fixtures.ReadFile("testdata/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// NewServer starts a fake provider server. Its URL can be passed to
+// illapaca.WithBaseURL or --provider-url; callers must Close it.
+func NewServer() *httptest.Server {
+	return httptest.NewServer(Handler())
+}
+
+// Handler serves WeatherAPI.com paths under /v1 and OpenWeatherMap paths
+// under /data/2.5 and /geo/1.0
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/forecast.json", weatherAPIForecast)
+	mux.HandleFunc("/v1/current.json", weatherAPIFixture("weatherapi_current.json"))
+	mux.HandleFunc("/v1/history.json", weatherAPIFixture("weatherapi_history.json"))
+	mux.HandleFunc("/geo/1.0/direct", owmGeo)
+	mux.HandleFunc("/data/2.5/weather", owmFixture("owm_weather.json"))
+	mux.HandleFunc("/data/2.5/forecast", owmFixture("owm_forecast.json"))
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// weatherAPIAuthorized rejects requests without the test key
+func weatherAPIAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.URL.Query().Get("key") != APIKey {
+		writeJSON(w, http.StatusUnauthorized, Fixture("weatherapi_error_key.json"))
+		return false
+	}
+	return true
+}
+
+// weatherAPIForecast serves forecasts trimmed to the requested days
+func weatherAPIForecast(w http.ResponseWriter, r *http.Request) {
+	if !weatherAPIAuthorized(w, r) {
+		return
+	}
+
+	switch strings.ToLower(r.URL.Query().Get("q")) {
+	case strings.ToLower(LocationOK):
+		days, err := strconv.Atoi(r.URL.Query().Get("days"))
+		if err != nil || days < 1 {
+			days = 1
+		}
+		writeJSON(w, http.StatusOK, trimForecast(Fixture("weatherapi_forecast.json"), days))
+	case strings.ToLower(LocationEmpty):
+		writeJSON(w, http.StatusOK, Fixture("weatherapi_forecast_empty.json"))
+	case strings.ToLower(LocationMalformed):
+		writeJSON(w, http.StatusOK, Fixture("weatherapi_forecast_malformed.json"))
+	default:
+		writeJSON(w, http.StatusBadRequest, Fixture("weatherapi_error_location.json"))
+	}
+}
+
+// weatherAPIFixture serves a fixture for the known location only
+func weatherAPIFixture(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !weatherAPIAuthorized(w, r) {
+			return
+		}
+		if !strings.EqualFold(r.URL.Query().Get("q"), LocationOK) {
+			writeJSON(w, http.StatusBadRequest, Fixture("weatherapi_error_location.json"))
+			return
+		}
+		writeJSON(w, http.StatusOK, Fixture(name))
+	}
+}
+
+// trimForecast keeps the first days forecast days of a fixture
+func trimForecast(body []byte, days int) []byte {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+
+	var forecast struct {
+		ForecastDay []json.RawMessage `json:"forecastday"`
+	}
+	if err := json.Unmarshal(response["forecast"], &forecast); err != nil {
+		return body
+	}
+	if days < len(forecast.ForecastDay) {
+		forecast.ForecastDay = forecast.ForecastDay[:days]
+	}
+
+	response["forecast"], _ = json.Marshal(forecast)
+	trimmed, _ := json.Marshal(response)
+	return trimmed
+}
+
+// owmAuthorized rejects requests without the test key
+func owmAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.URL.Query().Get("appid") != APIKey {
+		writeJSON(w, http.StatusUnauthorized, Fixture("owm_error_key.json"))
+		return false
+	}
+	return true
+}
+
+// owmGeo resolves only the known location; others return no results
+func owmGeo(w http.ResponseWriter, r *http.Request) {
+	if !owmAuthorized(w, r) {
+		return
+	}
+	if !strings.EqualFold(r.URL.Query().Get("q"), LocationOK) {
+		writeJSON(w, http.StatusOK, []byte("[]"))
+		return
+	}
+	writeJSON(w, http.StatusOK, Fixture("owm_geo.json"))
+}
+
+func owmFixture(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !owmAuthorized(w, r) {
+			return
+		}
+		writeJSON(w, http.StatusOK, Fixture(name))
+	}
+}
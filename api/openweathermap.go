@@ -0,0 +1,329 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+const (
+	// OpenWeatherMap base URL
+	owmBaseURL = "https://api.openweathermap.org/data/2.5"
+
+	// OpenWeatherMap geocoding URL
+	owmGeoURL = "https://api.openweathermap.org/geo/1.0"
+)
+
+// OpenWeatherMap fetches weather data from OpenWeatherMap's free APIs
+type OpenWeatherMap struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+	geoURL     string
+	lang       string
+}
+
+// Name returns the provider name
+func (p *OpenWeatherMap) Name() string {
+	return ProviderOpenWeatherMap
+}
+
+// SetLanguage asks for condition text in lang
+func (p *OpenWeatherMap) SetLanguage(lang string) {
+	p.lang = lang
+}
+
+// FetchWeather retrieves weather data from OpenWeatherMap and converts it
+// to the unified model. The free forecast covers at most five days.
+func (p *OpenWeatherMap) FetchWeather(ctx context.Context, location string, days int) (*model.WeatherData, error) {
+	coords, err := p.geocode(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{
+		"lat":   {fmt.Sprintf("%f", coords.Lat)},
+		"lon":   {fmt.Sprintf("%f", coords.Lon)},
+		"units": {"metric"},
+		"appid": {p.apiKey},
+	}
+	if p.lang != "" {
+		query.Set("lang", p.lang)
+	}
+
+	resp, err := get(ctx, p.httpClient, p.baseURL+"/weather", query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var current model.OpenWeatherCurrent
+	if err := json.NewDecoder(resp.Body).Decode(&current); err != nil {
+		return nil, err
+	}
+
+	forecastResp, err := get(ctx, p.httpClient, p.baseURL+"/forecast", query)
+	if err != nil {
+		return nil, err
+	}
+	defer forecastResp.Body.Close()
+
+	var forecast model.OpenWeatherForecast
+	if err := json.NewDecoder(forecastResp.Body).Decode(&forecast); err != nil {
+		return nil, err
+	}
+
+	return convertOpenWeather(coords, &current, &forecast, days), nil
+}
+
+// FetchHistoricalWeather is not available on OpenWeatherMap's free plan
+func (p *OpenWeatherMap) FetchHistoricalWeather(ctx context.Context, location, date string) (*model.HistoricalData, error) {
+	return nil, fmt.Errorf("historical data is only available from %s", ProviderWeatherAPI)
+}
+
+// ValidateKey checks the key with a current conditions request
+func (p *OpenWeatherMap) ValidateKey(ctx context.Context) error {
+	resp, err := get(ctx, p.httpClient, p.baseURL+"/weather", url.Values{
+		"appid": {p.apiKey},
+		"q":     {"London"},
+	})
+	if err != nil {
+		return keyError(ProviderOpenWeatherMap, err)
+	}
+	return resp.Body.Close()
+}
+
+// geocode resolves a location name to coordinates
+func (p *OpenWeatherMap) geocode(ctx context.Context, location string) (*model.Coordinates, error) {
+	resp, err := get(ctx, p.httpClient, p.geoURL+"/direct", url.Values{
+		"q":     {location},
+		"limit": {"1"},
+		"appid": {p.apiKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var results []model.GeoLocation
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, location)
+	}
+
+	return &model.Coordinates{
+		Lat:     results[0].Lat,
+		Lon:     results[0].Lon,
+		Name:    results[0].Name,
+		Country: results[0].Country,
+	}, nil
+}
+
+// convertOpenWeather maps OpenWeatherMap responses to the unified model.
+// The free forecast has 3-hour steps; each step is repeated for the hours
+// it covers so renderers can keep assuming hourly data.
+func convertOpenWeather(coords *model.Coordinates, current *model.OpenWeatherCurrent,
+	forecast *model.OpenWeatherForecast, days int) *model.WeatherData {
+
+	offset := time.Duration(current.Timezone) * time.Second
+	localTime := func(unix int) time.Time {
+		return time.Unix(int64(unix), 0).UTC().Add(offset)
+	}
+
+	condition := model.Condition{}
+	isDay := 1
+	if len(current.Weather) > 0 {
+		condition = openWeatherCondition(current.Weather[0].ID, current.Weather[0].Description)
+		if strings.HasSuffix(current.Weather[0].Icon, "n") {
+			isDay = 0
+		}
+	}
+
+	data := &model.WeatherData{
+		Current: model.CurrentWeather{
+			TempC:      current.Main.Temp,
+			TempF:      celsiusToFahrenheit(current.Main.Temp),
+			IsDay:      isDay,
+			Condition:  condition,
+			WindMph:    current.Wind.Speed * 2.23694,
+			WindKph:    current.Wind.Speed * 3.6,
+			WindDegree: int(math.Round(current.Wind.Deg)),
+			WindDir:    compassDirection(current.Wind.Deg),
+			GustKph:    current.Wind.Gust * 3.6,
+			PressureMb: float64(current.Main.Pressure),
+			PrecipMm:   current.Rain.OneHour + current.Snow.OneHour,
+			Humidity:   current.Main.Humidity,
+			FeelsLikeC: current.Main.FeelsLike,
+			FeelsLikeF: celsiusToFahrenheit(current.Main.FeelsLike),
+			VisKm:      float64(current.Visibility) / 1000,
+		},
+		Location: model.Location{
+			Name:           coords.Name,
+			Country:        coords.Country,
+			Lat:            coords.Lat,
+			Lon:            coords.Lon,
+			LocaltimeEpoch: int64(current.Dt),
+			Localtime:      localTime(current.Dt).Format("2006-01-02 15:04"),
+		},
+		Provider: ProviderOpenWeatherMap,
+	}
+
+	sunrise := localTime(forecast.City.Sunrise).Format("03:04 PM")
+	sunset := localTime(forecast.City.Sunset).Format("03:04 PM")
+
+	// Group forecast steps by local date
+	byDate := map[string][]model.OpenWeatherForecastItem{}
+	for _, item := range forecast.List {
+		date := localTime(item.Dt).Format("2006-01-02")
+		byDate[date] = append(byDate[date], item)
+	}
+
+	var dates []string
+	for date := range byDate {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	if len(dates) > days {
+		dates = dates[:days]
+	}
+
+	for _, date := range dates {
+		items := byDate[date]
+		day := model.ForecastDay{
+			Date:  date,
+			Astro: model.Astro{Sunrise: sunrise, Sunset: sunset},
+		}
+
+		dayStart, _ := time.Parse("2006-01-02", date)
+		day.DateEpoch = dayStart.Unix()
+
+		day.Day.MinTempC = math.Inf(1)
+		day.Day.MaxTempC = math.Inf(-1)
+		var sum float64
+		for _, item := range items {
+			day.Day.MaxTempC = math.Max(day.Day.MaxTempC, item.Main.TempMax)
+			day.Day.MinTempC = math.Min(day.Day.MinTempC, item.Main.TempMin)
+			day.Day.MaxWindKph = math.Max(day.Day.MaxWindKph, item.Wind.Speed*3.6)
+			day.Day.TotalPrecipMm += item.Rain.ThreeHour + item.Snow.ThreeHour
+			day.Day.DailyChanceOfRain = max(day.Day.DailyChanceOfRain, int(math.Round(item.Pop*100)))
+			sum += item.Main.Temp
+
+			step := localTime(item.Dt)
+			for h := 0; h < 3; h++ {
+				t := step.Add(time.Duration(h) * time.Hour)
+				if t.Format("2006-01-02") != date {
+					break
+				}
+				day.Hour = append(day.Hour, openWeatherHour(item, t, offset))
+			}
+		}
+		day.Day.AvgTempC = sum / float64(len(items))
+
+		// Use the condition closest to midday as the day's condition
+		midday := items[len(items)/2]
+		if len(midday.Weather) > 0 {
+			day.Day.Condition = openWeatherCondition(midday.Weather[0].ID, midday.Weather[0].Description)
+		}
+
+		data.Forecast.ForecastDay = append(data.Forecast.ForecastDay, day)
+	}
+
+	return data
+}
+
+// openWeatherHour converts one forecast step to an hourly entry at t
+func openWeatherHour(item model.OpenWeatherForecastItem, t time.Time, offset time.Duration) model.Hour {
+	hour := model.Hour{
+		TimeEpoch:    t.Add(-offset).Unix(),
+		Time:         t.Format("2006-01-02 15:04"),
+		TempC:        item.Main.Temp,
+		FeelsLikeC:   item.Main.FeelsLike,
+		ChanceOfRain: int(math.Round(item.Pop * 100)),
+		WindKph:      item.Wind.Speed * 3.6,
+		WindDegree:   int(math.Round(item.Wind.Deg)),
+		GustKph:      item.Wind.Gust * 3.6,
+	}
+	if item.Sys.Pod == "d" {
+		hour.IsDay = 1
+	}
+	if len(item.Weather) > 0 {
+		hour.Condition = openWeatherCondition(item.Weather[0].ID, item.Weather[0].Description)
+	}
+	return hour
+}
+
+// openWeatherCondition maps an OpenWeatherMap condition ID to the
+// WeatherAPI condition code used by the unified model
+func openWeatherCondition(id int, description string) model.Condition {
+	var code int
+	switch {
+	case id >= 200 && id < 300:
+		code = 1276 // Moderate or heavy rain with thunder
+	case id >= 300 && id < 400:
+		code = 1153 // Light drizzle
+	case id == 500:
+		code = 1183 // Light rain
+	case id == 501:
+		code = 1189 // Moderate rain
+	case id >= 502 && id <= 504:
+		code = 1195 // Heavy rain
+	case id == 511:
+		code = 1201 // Moderate or heavy freezing rain
+	case id >= 520 && id < 600:
+		code = 1243 // Moderate or heavy rain shower
+	case id == 600:
+		code = 1213 // Light snow
+	case id == 601:
+		code = 1219 // Moderate snow
+	case id == 602:
+		code = 1225 // Heavy snow
+	case id >= 611 && id <= 616:
+		code = 1204 // Light sleet
+	case id >= 620 && id < 700:
+		code = 1258 // Moderate or heavy snow showers
+	case id == 741:
+		code = 1135 // Fog
+	case id >= 700 && id < 800:
+		code = 1030 // Mist
+	case id == 800:
+		code = 1000 // Sunny / Clear
+	case id == 801:
+		code = 1003 // Partly cloudy
+	case id == 802:
+		code = 1006 // Cloudy
+	default:
+		code = 1009 // Overcast
+	}
+
+	// Capitalize the first letter, which may be accented in other languages
+	text := description
+	if r, size := utf8.DecodeRuneInString(text); size > 0 {
+		text = string(unicode.ToUpper(r)) + text[size:]
+	}
+
+	return model.Condition{Text: text, Code: code}
+}
+
+// compassDirection converts degrees to a 16-point compass direction
+func compassDirection(deg float64) string {
+	directions := []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+		"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	index := int(math.Round(math.Mod(deg, 360)/22.5)) % 16
+	return directions[index]
+}
+
+func celsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// Supported weather providers
+const (
+	ProviderWeatherAPI     = "weatherapi"
+	ProviderOpenWeatherMap = "openweathermap"
+)
+
+// Providers lists every supported provider name
+var Providers = []string{ProviderWeatherAPI, ProviderOpenWeatherMap}
+
+// ErrKeyRejected is returned by ValidateKey when the provider refuses the
+// key. Other errors mean the key could not be checked.
+var ErrKeyRejected = errors.New("key rejected")
+
+// ErrLocationNotFound is returned when the provider answers normally but
+// knows no location by the name asked for
+var ErrLocationNotFound = errors.New("location not found")
+
+// StatusError is a provider response with a status other than 200
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Body)
+}
+
+// SuppliesUV reports whether provider reports a UV index. OpenWeatherMap's
+// free API has none, so its UV values are always zero. Data from an
+// unknown provider is assumed to have one.
+func SuppliesUV(provider string) bool {
+	return provider != ProviderOpenWeatherMap
+}
+
+// Provider fetches weather data from one weather service and converts it
+// to the unified model
+type Provider interface {
+	// Name returns the provider name, e.g. "weatherapi"
+	Name() string
+
+	// FetchWeather returns current conditions and a forecast of up to days days
+	FetchWeather(ctx context.Context, location string, days int) (*model.WeatherData, error)
+
+	// FetchHistoricalWeather returns observed weather for a date (YYYY-MM-DD)
+	FetchHistoricalWeather(ctx context.Context, location, date string) (*model.HistoricalData, error)
+
+	// ValidateKey checks the API key with a minimal request
+	ValidateKey(ctx context.Context) error
+}
+
+// Localizer is implemented by providers that can describe conditions in
+// other languages
+type Localizer interface {
+	// SetLanguage asks for condition text in lang, a language code such as
+	// "es". An empty lang is the provider's default, English.
+	SetLanguage(lang string)
+}
+
+// ValidateProvider checks that name is a supported provider
+func ValidateProvider(name string) error {
+	for _, p := range Providers {
+		if p == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown provider %q (use %s or %s)", name, ProviderWeatherAPI, ProviderOpenWeatherMap)
+}
+
+// NewProvider returns the provider called name using apiKey. A nil
+// httpClient uses http.DefaultClient. A non-empty serverURL replaces the
+// provider's host, keeping its API paths (/v1 for WeatherAPI.com, /data/2.5
+// and /geo/1.0 for OpenWeatherMap), e.g. to point at a fake server.
+func NewProvider(name, apiKey string, httpClient *http.Client, serverURL string) (Provider, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	serverURL = strings.TrimSuffix(serverURL, "/")
+
+	switch name {
+	case ProviderWeatherAPI:
+		p := &WeatherAPI{apiKey: apiKey, httpClient: httpClient, baseURL: baseURL}
+		if serverURL != "" {
+			p.baseURL = serverURL + "/v1"
+		}
+		return p, nil
+	case ProviderOpenWeatherMap:
+		p := &OpenWeatherMap{apiKey: apiKey, httpClient: httpClient, baseURL: owmBaseURL, geoURL: owmGeoURL}
+		if serverURL != "" {
+			p.baseURL = serverURL + "/data/2.5"
+			p.geoURL = serverURL + "/geo/1.0"
+		}
+		return p, nil
+	default:
+		return nil, ValidateProvider(name)
+	}
+}
+
+// get performs a GET request and turns non-200 responses into errors.
+// Transport errors are unwrapped so the key in the query is never printed.
+func get(ctx context.Context, client *http.Client, endpoint string, query url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return nil, fmt.Errorf("request to %s failed: %w", endpoint, urlErr.Err)
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return resp, nil
+}
+
+// keyError describes a failed key check. Only 401 and 403 responses mean
+// the provider refused the key; timeouts, network failures and other
+// errors leave it unverified.
+func keyError(provider string, err error) error {
+	var status *StatusError
+	if errors.As(err, &status) && (status.StatusCode == http.StatusUnauthorized || status.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("%s: %w: %w", provider, ErrKeyRejected, err)
+	}
+	return fmt.Errorf("could not verify the key with %s: %w", provider, err)
+}
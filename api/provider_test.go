@@ -0,0 +1,192 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+)
+
+func newProvider(t *testing.T, name, key string) api.Provider {
+	t.Helper()
+	server := apitest.NewServer()
+	t.Cleanup(server.Close)
+
+	p, err := api.NewProvider(name, key, server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestWeatherAPIFetchWeather(t *testing.T) {
+	p := newProvider(t, api.ProviderWeatherAPI, apitest.APIKey)
+
+	data, err := p.FetchWeather(context.Background(), apitest.LocationOK, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Location.Name != "London" {
+		t.Errorf("location = %q, want London", data.Location.Name)
+	}
+	if data.Current.TempC != 16.2 || data.Current.Condition.Text != "Cloudy" {
+		t.Errorf("current = %.1f %q, want 16.2 Cloudy", data.Current.TempC, data.Current.Condition.Text)
+	}
+	if n := len(data.Forecast.ForecastDay); n != 2 {
+		t.Fatalf("got %d forecast days, want 2", n)
+	}
+	if n := len(data.Forecast.ForecastDay[0].Hour); n != 24 {
+		t.Errorf("got %d hours, want 24", n)
+	}
+}
+
+func TestWeatherAPIEmptyForecast(t *testing.T) {
+	p := newProvider(t, api.ProviderWeatherAPI, apitest.APIKey)
+
+	data, err := p.FetchWeather(context.Background(), apitest.LocationEmpty, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(data.Forecast.ForecastDay); n != 0 {
+		t.Errorf("got %d forecast days, want 0", n)
+	}
+}
+
+func TestWeatherAPIErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		location string
+		want     string
+	}{
+		{"malformed", apitest.APIKey, apitest.LocationMalformed, "invalid response"},
+		{"unknown location", apitest.APIKey, "Atlantis", "API error (400)"},
+		{"bad key", "wrong-key", apitest.LocationOK, "API error (401)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProvider(t, api.ProviderWeatherAPI, tt.key)
+
+			_, err := p.FetchWeather(context.Background(), tt.location, 1)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err, tt.want)
+			}
+			if strings.Contains(err.Error(), tt.key) {
+				t.Errorf("error %q leaks the API key", err)
+			}
+		})
+	}
+}
+
+func TestWeatherAPIHistory(t *testing.T) {
+	p := newProvider(t, api.ProviderWeatherAPI, apitest.APIKey)
+
+	data, err := p.FetchHistoricalWeather(context.Background(), apitest.LocationOK, "2025-10-10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(data.Forecast.ForecastDay); n != 1 || data.Forecast.ForecastDay[0].Date != "2025-10-10" {
+		t.Errorf("unexpected history days: %+v", data.Forecast.ForecastDay)
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, name := range api.Providers {
+		t.Run(name, func(t *testing.T) {
+			if err := newProvider(t, name, apitest.APIKey).ValidateKey(context.Background()); err != nil {
+				t.Errorf("valid key rejected: %v", err)
+			}
+			if err := newProvider(t, name, "wrong-key").ValidateKey(context.Background()); !errors.Is(err, api.ErrKeyRejected) {
+				t.Errorf("invalid key: err = %v, want ErrKeyRejected", err)
+			}
+
+			// An outage says nothing about the key
+			down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			}))
+			defer down.Close()
+			p, err := api.NewProvider(name, apitest.APIKey, down.Client(), down.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.ValidateKey(context.Background()); err == nil || errors.Is(err, api.ErrKeyRejected) {
+				t.Errorf("provider down: err = %v, want an error other than ErrKeyRejected", err)
+			}
+		})
+	}
+}
+
+func TestOpenWeatherMapFetchWeather(t *testing.T) {
+	p := newProvider(t, api.ProviderOpenWeatherMap, apitest.APIKey)
+
+	data, err := p.FetchWeather(context.Background(), apitest.LocationOK, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Location.Name != "London" || data.Location.Country != "GB" {
+		t.Errorf("location = %q, %q, want London, GB", data.Location.Name, data.Location.Country)
+	}
+	if n := len(data.Forecast.ForecastDay); n != 3 {
+		t.Fatalf("got %d forecast days, want 3", n)
+	}
+	for _, day := range data.Forecast.ForecastDay {
+		if day.Day.MaxTempC < day.Day.MinTempC {
+			t.Errorf("%s: max %.1f below min %.1f", day.Date, day.Day.MaxTempC, day.Day.MinTempC)
+		}
+		if day.Astro.Sunrise == "" || day.Astro.Sunset == "" {
+			t.Errorf("%s: missing astronomy", day.Date)
+		}
+	}
+}
+
+func TestOpenWeatherMapUnknownLocation(t *testing.T) {
+	p := newProvider(t, api.ProviderOpenWeatherMap, apitest.APIKey)
+
+	if _, err := p.FetchWeather(context.Background(), "Atlantis", 1); err == nil {
+		t.Fatal("expected an error for an unknown location")
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	for _, name := range api.Providers {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var langs []string
+			handler := apitest.Handler()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				langs = append(langs, r.URL.Query().Get("lang"))
+				mu.Unlock()
+				handler.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			p, err := api.NewProvider(name, apitest.APIKey, server.Client(), server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.(api.Localizer).SetLanguage("es")
+			if _, err := p.FetchWeather(context.Background(), apitest.LocationOK, 1); err != nil {
+				t.Fatal(err)
+			}
+
+			// Geocoding does not translate, so only weather requests count
+			var asked bool
+			for _, lang := range langs {
+				asked = asked || lang == "es"
+			}
+			if !asked {
+				t.Errorf("no request asked for es: %q", langs)
+			}
+		})
+	}
+}
@@ -1,15 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"time"
+	"net/url"
+	"strconv"
 
-	"github.com/biferdou/illapaca/config"
 	"github.com/biferdou/illapaca/model"
-	"github.com/briandowns/spinner"
 )
 
 const (
@@ -17,37 +16,47 @@ const (
 	baseURL = "https://api.weatherapi.com/v1"
 )
 
-// FetchWeather retrieves weather data from the API
-func FetchWeather(location string, days int) (*model.WeatherData, error) {
-	if config.AppConfig.APIKey == "" {
-		return nil, fmt.Errorf("API key not set. Use --api-key flag or set ILLAPA_API_KEY environment variable")
-	}
+// WeatherAPI fetches weather data from WeatherAPI.com
+type WeatherAPI struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+	lang       string
+}
 
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Prefix = "Fetching weather data "
-	s.Start()
-	defer s.Stop()
+// Name returns the provider name
+func (p *WeatherAPI) Name() string {
+	return ProviderWeatherAPI
+}
 
-	// Construct forecast URL
-	forecastURL := fmt.Sprintf("%s/forecast.json?key=%s&q=%s&days=%d&aqi=no&alerts=no",
-		baseURL, config.AppConfig.APIKey, location, days)
+// SetLanguage asks for condition text in lang
+func (p *WeatherAPI) SetLanguage(lang string) {
+	p.lang = lang
+}
 
+// FetchWeather retrieves weather data from the API
+func (p *WeatherAPI) FetchWeather(ctx context.Context, location string, days int) (*model.WeatherData, error) {
 	// Make request
-	resp, err := http.Get(forecastURL)
+	query := url.Values{
+		"key":    {p.apiKey},
+		"q":      {location},
+		"days":   {strconv.Itoa(days)},
+		"aqi":    {"no"},
+		"alerts": {"no"},
+	}
+	if p.lang != "" {
+		query.Set("lang", p.lang)
+	}
+	resp, err := get(ctx, p.httpClient, p.baseURL+"/forecast.json", query)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
-	}
-
 	// Parse response
 	var response weatherAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid response from %s: %w", ProviderWeatherAPI, err)
 	}
 
 	// Convert to our unified model
@@ -59,7 +68,9 @@ func FetchWeather(location string, days int) (*model.WeatherData, error) {
 			Condition:  response.Current.Condition,
 			WindMph:    response.Current.WindMph,
 			WindKph:    response.Current.WindKph,
+			WindDegree: response.Current.WindDegree,
 			WindDir:    response.Current.WindDir,
+			GustKph:    response.Current.GustKph,
 			PressureMb: response.Current.PressureMb,
 			PrecipMm:   response.Current.PrecipMm,
 			Humidity:   response.Current.Humidity,
@@ -70,42 +81,33 @@ func FetchWeather(location string, days int) (*model.WeatherData, error) {
 		},
 		Location: response.Location,
 		Forecast: response.Forecast,
+		Provider: ProviderWeatherAPI,
 	}
 
 	return weatherData, nil
 }
 
 // FetchHistoricalWeather retrieves historical weather data
-func FetchHistoricalWeather(location string, date string) (*model.HistoricalData, error) {
-	if config.AppConfig.APIKey == "" {
-		return nil, fmt.Errorf("API key not set. Use --api-key flag or set ILLAPA_API_KEY environment variable")
-	}
-
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	s.Prefix = "Fetching historical data "
-	s.Start()
-	defer s.Stop()
-
-	// Construct historical URL
-	historicalURL := fmt.Sprintf("%s/history.json?key=%s&q=%s&dt=%s",
-		baseURL, config.AppConfig.APIKey, location, date)
-
+func (p *WeatherAPI) FetchHistoricalWeather(ctx context.Context, location, date string) (*model.HistoricalData, error) {
 	// Make request
-	resp, err := http.Get(historicalURL)
+	query := url.Values{
+		"key": {p.apiKey},
+		"q":   {location},
+		"dt":  {date},
+	}
+	if p.lang != "" {
+		query.Set("lang", p.lang)
+	}
+	resp, err := get(ctx, p.httpClient, p.baseURL+"/history.json", query)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
-	}
-
 	// Parse response
 	var response weatherAPIHistoricalResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid response from %s: %w", ProviderWeatherAPI, err)
 	}
 
 	// Convert to our unified model
@@ -117,6 +119,18 @@ func FetchHistoricalWeather(location string, date string) (*model.HistoricalData
 	return historicalData, nil
 }
 
+// ValidateKey checks the key with a current conditions request
+func (p *WeatherAPI) ValidateKey(ctx context.Context) error {
+	resp, err := get(ctx, p.httpClient, p.baseURL+"/current.json", url.Values{
+		"key": {p.apiKey},
+		"q":   {"London"},
+	})
+	if err != nil {
+		return keyError(ProviderWeatherAPI, err)
+	}
+	return resp.Body.Close()
+}
+
 // WeatherAPI response models
 type weatherAPIResponse struct {
 	Location model.Location    `json:"location"`
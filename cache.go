@@ -0,0 +1,208 @@
+package illapaca
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// Cache stores fetched weather data by request key
+type Cache interface {
+	Get(key string) (*model.WeatherData, bool)
+	Set(key string, data *model.WeatherData)
+}
+
+// Recorder keeps freshly fetched weather, e.g. in a history database
+type Recorder interface {
+	Record(provider, location string, data *model.WeatherData) error
+}
+
+// CacheKey identifies a forecast request, as passed to Cache.Get and Set
+func CacheKey(provider, location string, days int) string {
+	return fmt.Sprintf("%s|%s|%d", provider, strings.ToLower(strings.TrimSpace(location)), days)
+}
+
+// LanguageCacheKey identifies a forecast request for condition text in
+// lang. An empty lang is the provider's default language.
+func LanguageCacheKey(provider, lang, location string, days int) string {
+	key := CacheKey(provider, location, days)
+	if lang != "" {
+		key += "|" + lang
+	}
+	return key
+}
+
+// MemoryCache is an in-process Cache whose entries expire after a TTL.
+// A bounded cache holds at most its limit of entries, evicting the least
+// recently used.
+type MemoryCache struct {
+	ttl     time.Duration
+	limit   int
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // most recently used first
+}
+
+type memoryEntry struct {
+	key     string
+	data    *model.WeatherData
+	expires time.Time
+}
+
+// NewMemoryCache creates an unbounded MemoryCache keeping entries for ttl
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return NewBoundedMemoryCache(ttl, 0)
+}
+
+// NewBoundedMemoryCache creates a MemoryCache keeping entries for ttl and
+// holding at most limit of them. A limit of 0 means no limit.
+func NewBoundedMemoryCache(ttl time.Duration, limit int) *MemoryCache {
+	return &MemoryCache{ttl: ttl, limit: limit, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// Get returns a cached entry that has not expired
+func (c *MemoryCache) Get(key string) (*model.WeatherData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.data, true
+}
+
+// Set stores data under key, evicting the least recently used entry when
+// the cache is full
+func (c *MemoryCache) Set(key string, data *model.WeatherData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryEntry{key: key, data: data, expires: time.Now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.limit > 0 && c.order.Len() > c.limit {
+		c.remove(c.order.Back())
+	}
+}
+
+// Sweep drops every expired entry. Entries otherwise stay until they are
+// read or evicted, so long-running users should sweep periodically.
+func (c *MemoryCache) Sweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		if now.After(elem.Value.(*memoryEntry).expires) {
+			c.remove(elem)
+		}
+		elem = next
+	}
+}
+
+// Len returns the number of entries held, including expired ones not yet
+// swept
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *MemoryCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*memoryEntry).key)
+}
+
+// FileCache is a Cache kept as one JSON file per entry in a directory, so
+// that separate processes (such as shell prompts) share it
+type FileCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type fileEntry struct {
+	FetchedAt time.Time          `json:"fetched_at"`
+	Data      *model.WeatherData `json:"data"`
+}
+
+// NewFileCache creates a FileCache in dir whose entries are fresh for ttl
+func NewFileCache(dir string, ttl time.Duration) *FileCache {
+	return &FileCache{dir: dir, ttl: ttl}
+}
+
+// Path returns the file holding key
+func (c *FileCache) Path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// Load returns the entry for key whatever its age, with the time it was
+// fetched
+func (c *FileCache) Load(key string) (*model.WeatherData, time.Time, bool) {
+	raw, err := os.ReadFile(c.Path(key))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	var entry fileEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Data == nil {
+		return nil, time.Time{}, false
+	}
+	return entry.Data, entry.FetchedAt, true
+}
+
+// Get returns an entry younger than the TTL
+func (c *FileCache) Get(key string) (*model.WeatherData, bool) {
+	data, fetchedAt, ok := c.Load(key)
+	if !ok || time.Since(fetchedAt) > c.ttl {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores data under key. The file is replaced atomically so readers
+// never see a partial entry; write errors are ignored like cache misses.
+func (c *FileCache) Set(key string, data *model.WeatherData) {
+	raw, err := json.Marshal(fileEntry{FetchedAt: time.Now(), Data: data})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.Path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
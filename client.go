@@ -0,0 +1,177 @@
+// Package illapaca is the library core of the Illapaca weather dashboard.
+// A Client fetches weather from a provider into the unified model, and the
+// ui package renders that model to any io.Writer.
+//
+//	client, err := illapaca.New(
+//		illapaca.WithProvider(api.ProviderWeatherAPI, key),
+//		illapaca.WithCache(illapaca.NewMemoryCache(10*time.Minute)),
+//	)
+//	data, err := client.FetchWeather(ctx, "Cusco", 3)
+package illapaca
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
+)
+
+// Client fetches weather data through a provider, with optional caching
+type Client struct {
+	providerName string
+	apiKey       string
+	units        string
+	lang         string
+	httpClient   *http.Client
+	baseURL      string
+	cache        Cache
+	recorder     Recorder
+	provider     api.Provider
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithProvider selects the weather provider and its API key
+func WithProvider(name, apiKey string) Option {
+	return func(c *Client) {
+		c.providerName = name
+		c.apiKey = apiKey
+	}
+}
+
+// WithUnits records the caller's preferred units (metric or imperial). The
+// unified model carries both, so this only affects consumers that ask.
+func WithUnits(units string) Option {
+	return func(c *Client) {
+		c.units = units
+	}
+}
+
+// WithLanguage asks the provider for condition text in lang, a language
+// code such as "es". Providers that cannot translate ignore it.
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.lang = lang
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for provider requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sends provider requests to another server, such as the fake
+// server in api/apitest. The provider's API paths are kept.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithCache caches responses so repeated requests skip the provider
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithRecorder passes every response fetched from the provider to
+// recorder. Cached responses are not recorded again.
+func WithRecorder(recorder Recorder) Option {
+	return func(c *Client) {
+		c.recorder = recorder
+	}
+}
+
+// New creates a Client. Without options it uses WeatherAPI.com with no
+// key, metric units, http.DefaultClient and no cache.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		providerName: api.ProviderWeatherAPI,
+		units:        "metric",
+		httpClient:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.units != "metric" && c.units != "imperial" {
+		return nil, fmt.Errorf("units must be metric or imperial, got %q", c.units)
+	}
+
+	provider, err := api.NewProvider(c.providerName, c.apiKey, c.httpClient, c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if l, ok := provider.(api.Localizer); ok && c.lang != "" {
+		l.SetLanguage(c.lang)
+	}
+	c.provider = provider
+
+	return c, nil
+}
+
+// Provider returns the name of the provider in use
+func (c *Client) Provider() string {
+	return c.provider.Name()
+}
+
+// Units returns the preferred units
+func (c *Client) Units() string {
+	return c.units
+}
+
+// FetchWeather returns current conditions and a forecast of up to days days
+func (c *Client) FetchWeather(ctx context.Context, location string, days int) (*model.WeatherData, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("API key not set for %s", c.provider.Name())
+	}
+
+	if c.cache != nil {
+		if data, ok := c.cache.Get(LanguageCacheKey(c.provider.Name(), c.lang, location, days)); ok {
+			return data, nil
+		}
+	}
+	return c.RefreshWeather(ctx, location, days)
+}
+
+// RefreshWeather fetches from the provider even when the cache holds a
+// fresh entry, and caches the result for later FetchWeather calls
+func (c *Client) RefreshWeather(ctx context.Context, location string, days int) (*model.WeatherData, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("API key not set for %s", c.provider.Name())
+	}
+
+	data, err := c.provider.FetchWeather(ctx, location, days)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cache != nil {
+		c.cache.Set(LanguageCacheKey(c.provider.Name(), c.lang, location, days), data)
+	}
+	if c.recorder != nil {
+		// History is best effort; a busy or unwritable store must not
+		// fail the fetch
+		_ = c.recorder.Record(c.provider.Name(), location, data)
+	}
+	return data, nil
+}
+
+// FetchHistoricalWeather returns observed weather for a date (YYYY-MM-DD)
+func (c *Client) FetchHistoricalWeather(ctx context.Context, location, date string) (*model.HistoricalData, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("API key not set for %s", c.provider.Name())
+	}
+	return c.provider.FetchHistoricalWeather(ctx, location, date)
+}
+
+// ValidateKey checks the API key against the provider
+func (c *Client) ValidateKey(ctx context.Context) error {
+	return c.provider.ValidateKey(ctx)
+}
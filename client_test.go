@@ -0,0 +1,128 @@
+package illapaca_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/model"
+)
+
+func TestClientCache(t *testing.T) {
+	var requests atomic.Int32
+	handler := apitest.Handler()
+	server := httptestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+
+	client, err := illapaca.New(
+		illapaca.WithProvider(api.ProviderWeatherAPI, apitest.APIKey),
+		illapaca.WithBaseURL(server),
+		illapaca.WithCache(illapaca.NewMemoryCache(time.Minute)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if _, err := client.FetchWeather(context.Background(), apitest.LocationOK, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+}
+
+func TestClientMissingKey(t *testing.T) {
+	client, err := illapaca.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.FetchWeather(context.Background(), apitest.LocationOK, 1); err == nil {
+		t.Fatal("expected an error without an API key")
+	}
+}
+
+func TestNewRejectsBadOptions(t *testing.T) {
+	if _, err := illapaca.New(illapaca.WithUnits("kelvin")); err == nil {
+		t.Error("expected an error for unknown units")
+	}
+	if _, err := illapaca.New(illapaca.WithProvider("darksky", "key")); err == nil {
+		t.Error("expected an error for an unknown provider")
+	}
+}
+
+// httptestServer starts handler and returns its URL
+func httptestServer(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	key := illapaca.CacheKey(api.ProviderWeatherAPI, "Cusco", 1)
+	data := &model.WeatherData{Location: model.Location{Name: "Cusco"}}
+
+	if _, _, ok := illapaca.NewFileCache(dir, time.Minute).Load(key); ok {
+		t.Fatal("empty cache returned an entry")
+	}
+	illapaca.NewFileCache(dir, time.Minute).Set(key, data)
+
+	// A second instance, as in another process, sees the entry
+	fresh := illapaca.NewFileCache(dir, time.Minute)
+	if got, ok := fresh.Get(key); !ok || got.Location.Name != "Cusco" {
+		t.Errorf("Get = %v, %v", got, ok)
+	}
+
+	expired := illapaca.NewFileCache(dir, 0)
+	if _, ok := expired.Get(key); ok {
+		t.Error("Get returned an expired entry")
+	}
+	got, fetchedAt, ok := expired.Load(key)
+	if !ok || got.Location.Name != "Cusco" || time.Since(fetchedAt) > time.Minute {
+		t.Errorf("Load = %v, %v, %v", got, fetchedAt, ok)
+	}
+}
+
+func TestMemoryCacheLimit(t *testing.T) {
+	cache := illapaca.NewBoundedMemoryCache(time.Minute, 2)
+	for _, name := range []string{"Cusco", "Lima", "Quito"} {
+		cache.Set(illapaca.CacheKey(api.ProviderWeatherAPI, name, 1), &model.WeatherData{Location: model.Location{Name: name}})
+		if name == "Lima" {
+			// Reading Cusco makes Lima the least recently used
+			cache.Get(illapaca.CacheKey(api.ProviderWeatherAPI, "Cusco", 1))
+		}
+	}
+
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len = %d, want the limit of 2", n)
+	}
+	for name, want := range map[string]bool{"Cusco": true, "Lima": false, "Quito": true} {
+		if _, ok := cache.Get(illapaca.CacheKey(api.ProviderWeatherAPI, name, 1)); ok != want {
+			t.Errorf("Get(%s) found = %v, want %v", name, ok, want)
+		}
+	}
+}
+
+func TestMemoryCacheSweep(t *testing.T) {
+	cache := illapaca.NewMemoryCache(0)
+	for _, name := range []string{"Cusco", "Lima"} {
+		cache.Set(illapaca.CacheKey(api.ProviderWeatherAPI, name, 1), &model.WeatherData{})
+	}
+	time.Sleep(time.Millisecond)
+
+	cache.Sweep()
+	if n := cache.Len(); n != 0 {
+		t.Errorf("Len = %d after sweeping expired entries, want 0", n)
+	}
+}
@@ -0,0 +1,122 @@
+// Package climate computes daily normals, percentiles and records for a
+// location from observed days, such as those kept in the history database.
+package climate
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// DefaultWindow is how many days either side of a calendar day count
+// towards its normals and percentiles
+const DefaultWindow = 7
+
+// MinSamples is the fewest observed days a calendar day needs before it
+// gets statistics
+const MinSamples = 5
+
+// daysInYear covers Feb 29, using 2000 as the reference leap year
+const daysInYear = 366
+
+// yearDay returns the zero-based position of a YYYY-MM-DD date's calendar
+// day within a leap year
+func yearDay(date string) (int, bool) {
+	if len(date) != len("2006-01-02") {
+		return 0, false
+	}
+	t, err := time.Parse("2006-01-02", "2000"+date[4:])
+	if err != nil {
+		return 0, false
+	}
+	return t.YearDay() - 1, true
+}
+
+// distance is the number of days between two calendar days, wrapping
+// around the end of the year
+func distance(a, b int) int {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	return min(d, daysInYear-d)
+}
+
+// percentile returns the p-th percentile (0-100) of sorted values using
+// linear interpolation
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// Compute builds statistics for every calendar day that has at least
+// minSamples observed days within window days of it
+func Compute(location string, days []model.ObservedDay, window, minSamples int) *model.Climate {
+	climate := &model.Climate{Location: location, Days: map[string]model.ClimateDay{}}
+
+	type sample struct {
+		yearDay int
+		day     model.ObservedDay
+	}
+	var samples []sample
+	for _, day := range days {
+		if yd, ok := yearDay(day.Date); ok {
+			samples = append(samples, sample{yd, day})
+		}
+	}
+
+	reference := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for target := range daysInYear {
+		var avg []float64
+		var sumMax, sumMin float64
+		stats := model.ClimateDay{MonthDay: reference.AddDate(0, 0, target).Format("01-02")}
+
+		for _, s := range samples {
+			if distance(s.yearDay, target) > window {
+				continue
+			}
+			avg = append(avg, s.day.AvgTempC)
+			sumMax += s.day.MaxTempC
+			sumMin += s.day.MinTempC
+
+			if s.yearDay != target {
+				continue
+			}
+			if stats.RecordHighDate == "" || s.day.MaxTempC > stats.RecordHighC {
+				stats.RecordHighC, stats.RecordHighDate = s.day.MaxTempC, s.day.Date
+			}
+			if stats.RecordLowDate == "" || s.day.MinTempC < stats.RecordLowC {
+				stats.RecordLowC, stats.RecordLowDate = s.day.MinTempC, s.day.Date
+			}
+		}
+
+		if len(avg) < max(minSamples, 1) {
+			continue
+		}
+
+		n := float64(len(avg))
+		var sumAvg float64
+		for _, a := range avg {
+			sumAvg += a
+		}
+		sort.Float64s(avg)
+
+		stats.Samples = len(avg)
+		stats.NormalTempC = sumAvg / n
+		stats.NormalMaxC = sumMax / n
+		stats.NormalMinC = sumMin / n
+		stats.P10TempC = percentile(avg, 10)
+		stats.P50TempC = percentile(avg, 50)
+		stats.P90TempC = percentile(avg, 90)
+		climate.Days[stats.MonthDay] = stats
+	}
+
+	return climate
+}
@@ -0,0 +1,69 @@
+package climate
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+func TestCompute(t *testing.T) {
+	var days []model.ObservedDay
+	// Three years of Oct 14-20, warmer each year
+	for year := 2022; year <= 2024; year++ {
+		offset := float64(year - 2022)
+		for d := 14; d <= 20; d++ {
+			days = append(days, model.ObservedDay{
+				Date:     fmt.Sprintf("%d-10-%02d", year, d),
+				AvgTempC: 10 + offset,
+				MaxTempC: 15 + offset,
+				MinTempC: 5 + offset,
+			})
+		}
+	}
+
+	c := Compute("Cusco", days, 3, 5)
+
+	day, ok := c.Day("2025-10-17")
+	if !ok {
+		t.Fatal("no statistics for Oct 17")
+	}
+	if day.Samples != 21 {
+		t.Errorf("samples = %d, want 21", day.Samples)
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"normal", day.NormalTempC, 11},
+		{"normal max", day.NormalMaxC, 16},
+		{"normal min", day.NormalMinC, 6},
+		{"p50", day.P50TempC, 11},
+		{"p10", day.P10TempC, 10},
+		{"p90", day.P90TempC, 12},
+		{"record high", day.RecordHighC, 17},
+		{"record low", day.RecordLowC, 5},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if day.RecordHighDate != "2024-10-17" || day.RecordLowDate != "2022-10-17" {
+		t.Errorf("record dates = %s, %s", day.RecordHighDate, day.RecordLowDate)
+	}
+
+	// Oct 11 only reaches Oct 14 within the window: 3 samples
+	if _, ok := c.Day("10-11"); ok {
+		t.Error("Oct 11 has statistics from too few samples")
+	}
+}
+
+func TestDistanceWraps(t *testing.T) {
+	dec31, _ := yearDay("2024-12-31")
+	jan1, _ := yearDay("2025-01-01")
+	if d := distance(dec31, jan1); d != 1 {
+		t.Errorf("distance Dec 31 to Jan 1 = %d, want 1", d)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/biferdou/illapaca/history"
+	"github.com/spf13/cobra"
+)
+
+var accuracyCmd = &cobra.Command{
+	Use:   "accuracy [location]",
+	Short: "Compare recorded forecasts with what was observed",
+	Long: `Score the forecasts kept in the local history database against the
+conditions later recorded for the same days. Errors in maximum and minimum
+temperature and in chance of rain are reported as mean absolute error (MAE)
+and bias per provider and lead time. Record regularly with 'illapaca log',
+ideally with each provider, to build up samples.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+
+		days, _ := cmd.Flags().GetInt("days")
+
+		store, err := history.Open(historyPath())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+
+		from := time.Now().AddDate(0, 0, -days)
+		observations, err := store.Observations(location, from, time.Time{})
+		if err != nil {
+			fmt.Printf("Error reading history: %v\n", err)
+			os.Exit(1)
+		}
+		forecasts, err := store.Forecasts(location, from, time.Time{})
+		if err != nil {
+			fmt.Printf("Error reading history: %v\n", err)
+			os.Exit(1)
+		}
+
+		observed := history.MinReadings(history.DailySummaries(observations), minReadings(cmd))
+
+		newRenderer(cmd).DisplayAccuracy(location, history.Accuracy(forecasts, observed))
+	},
+}
+
+func init() {
+	accuracyCmd.Flags().IntP("days", "d", 30, "Number of past days to evaluate")
+	accuracyCmd.Flags().Int("min-readings", 0, "Ignore observed days with fewer recorded readings (default history.min_readings)")
+}
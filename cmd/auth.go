@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/keystore"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth [command]",
+	Short: "Manage provider API keys",
+	Long: `Store API keys in the system keyring, or in a passphrase-encrypted
+file when no keyring is available. Available commands:
+  login   - Validate and store an API key
+  logout  - Remove a stored API key
+  status  - Show where each provider's key comes from`,
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Validate and store an API key",
+	Run: func(cmd *cobra.Command, args []string) {
+		provider, _ := cmd.Flags().GetString("provider")
+		if provider == "" {
+			provider = config.AppConfig.Provider
+		}
+		backend, _ := cmd.Flags().GetString("store")
+		noVerify, _ := cmd.Flags().GetBool("no-verify")
+		key, _ := cmd.Flags().GetString("key")
+
+		if err := api.ValidateProvider(provider); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if key == "" {
+			var err error
+			key, err = readAPIKey(provider)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		if !noVerify {
+			err := validateKey(cmd.Context(), provider, key)
+			if errors.Is(err, api.ErrKeyRejected) {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if err != nil {
+				fmt.Printf("Error: %v (use --no-verify to store the key anyway)\n", err)
+				return
+			}
+		}
+
+		store, err := keystore.Open(backend)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if err := config.StoreAPIKey(store, provider, key); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Stored %s API key for profile %s in %s\n", provider, config.AppConfig.Profile, store.Name())
+
+		// Drop the old plaintext copy now that the key is stored securely
+		if provider == config.AppConfig.Provider {
+			removed, err := config.RemovePlaintextAPIKey()
+			if err != nil {
+				fmt.Printf("Warning: could not remove api_key from %s: %v\n", config.ConfigFilePath(), err)
+			} else if removed {
+				fmt.Printf("Removed plaintext api_key from %s\n", config.ConfigFilePath())
+			}
+		}
+	},
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove a stored API key",
+	Run: func(cmd *cobra.Command, args []string) {
+		provider, _ := cmd.Flags().GetString("provider")
+		if provider == "" {
+			provider = config.AppConfig.Provider
+		}
+
+		if err := api.ValidateProvider(provider); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		store, err := config.DeleteAPIKey(provider)
+		if errors.Is(err, keystore.ErrNotFound) {
+			fmt.Printf("No %s API key stored for profile %s\n", provider, config.AppConfig.Profile)
+			return
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Removed %s API key for profile %s from %s\n", provider, config.AppConfig.Profile, store.Name())
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show where each provider's key comes from",
+	Run: func(cmd *cobra.Command, args []string) {
+		check, _ := cmd.Flags().GetBool("check")
+
+		fmt.Printf("Profile: %s\n\n", config.AppConfig.Profile)
+		for _, provider := range api.Providers {
+			fmt.Printf("%-16s %s", provider, config.APIKeySource(provider))
+
+			if check {
+				key, err := config.ResolveAPIKey(provider)
+				if err == nil {
+					err := validateKey(cmd.Context(), provider, key)
+					switch {
+					case errors.Is(err, api.ErrKeyRejected):
+						fmt.Print(" (invalid)")
+					case err != nil:
+						fmt.Print(" (could not verify)")
+					default:
+						fmt.Print(" (valid)")
+					}
+				}
+			}
+			fmt.Println()
+		}
+
+		if config.HasPlaintextAPIKey() {
+			fmt.Printf("\nWarning: %s contains a plaintext api_key; run 'illapaca auth login' to move it\n",
+				config.ConfigFilePath())
+		}
+	},
+}
+
+// readAPIKey prompts for a key without echo, or reads it from piped stdin
+func readAPIKey(provider string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprintf(os.Stderr, "%s API key: ", provider)
+		key, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(key)), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("no API key given")
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func init() {
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authStatusCmd)
+
+	authLoginCmd.Flags().String("provider", "", "Weather provider the key belongs to (default is the configured provider)")
+	authLoginCmd.Flags().String("key", "", "API key (prompted for when omitted)")
+	authLoginCmd.Flags().String("store", keystore.BackendAuto, "Where to store the key (auto, keyring or file)")
+	authLoginCmd.Flags().Bool("no-verify", false, "Store the key without checking it against the provider")
+
+	authLogoutCmd.Flags().String("provider", "", "Weather provider the key belongs to (default is the configured provider)")
+
+	authStatusCmd.Flags().Bool("check", false, "Validate each key against its provider (valid, invalid or could not verify)")
+}
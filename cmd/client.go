@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/history"
+	"github.com/biferdou/illapaca/i18n"
+	"github.com/biferdou/illapaca/model"
+	"github.com/biferdou/illapaca/ui"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+// newClient builds a library client from the active configuration. With
+// record set, fetched weather is also kept in the history database when
+// history is enabled.
+func newClient(record bool) (*illapaca.Client, error) {
+	key, err := config.ResolveAPIKey(config.AppConfig.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// newRenderer reports an unknown language; fall back to English quietly
+	locale, _ := loadLocale()
+	opts := []illapaca.Option{
+		illapaca.WithProvider(config.AppConfig.Provider, key),
+		illapaca.WithUnits(config.AppConfig.Units),
+		illapaca.WithBaseURL(config.AppConfig.ProviderURL),
+		illapaca.WithLanguage(locale.ProviderLang),
+	}
+	if record && config.AppConfig.History.Enabled {
+		opts = append(opts, illapaca.WithRecorder(history.Recorder{Path: historyPath()}))
+	}
+	return illapaca.New(opts...)
+}
+
+// historyPath returns the configured history database file
+func historyPath() string {
+	if config.AppConfig.History.Path != "" {
+		return config.AppConfig.History.Path
+	}
+	return history.DefaultPath()
+}
+
+// fetchWeather retrieves weather for location while showing a spinner
+func fetchWeather(ctx context.Context, location string, days int) (*model.WeatherData, error) {
+	client, err := newClient(true)
+	if err != nil {
+		return nil, err
+	}
+
+	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond, spinner.WithWriter(os.Stderr))
+	s.Prefix = "Fetching weather data "
+	s.Start()
+	defer s.Stop()
+
+	return client.FetchWeather(ctx, location, days)
+}
+
+// validateKey checks key against provider
+func validateKey(ctx context.Context, provider, key string) error {
+	client, err := illapaca.New(
+		illapaca.WithProvider(provider, key),
+		illapaca.WithBaseURL(config.AppConfig.ProviderURL),
+	)
+	if err != nil {
+		return err
+	}
+	return client.ValidateKey(ctx)
+}
+
+// newRenderer returns a renderer for the command's output using the
+// active settings
+func newRenderer(cmd *cobra.Command) *ui.Renderer {
+	theme, err := loadTheme(config.AppConfig.Theme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using the default theme\n", err)
+	}
+	icons, err := ui.LookupIconSet(config.AppConfig.Icons)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using emoji\n", err)
+	}
+	locale, err := loadLocale()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using English\n", err)
+	}
+	return ui.NewRenderer(cmd.OutOrStdout(), ui.Settings{
+		Units:           config.AppConfig.Units,
+		AlertThresholds: config.AppConfig.AlertThresholds,
+		NoColor:         config.AppConfig.NoColor || ui.NoColorRequested(),
+		Theme:           theme,
+		ColorDepth:      ui.DetectColorDepth(),
+		Icons:           icons,
+		Accessible:      config.AppConfig.Accessible,
+		Locale:          locale,
+		Width:           ui.DetectWidth(),
+	})
+}
+
+// loadLocale returns the locale named by --lang or the lang setting, or
+// else the one in the environment (LC_ALL, LC_MESSAGES, LANG). An unknown
+// language falls back to English along with the error.
+func loadLocale() (*i18n.Locale, error) {
+	if config.AppConfig.Lang == "" {
+		return i18n.FromEnv(), nil
+	}
+	locale, err := i18n.Lookup(config.AppConfig.Lang)
+	if err != nil {
+		return i18n.English, err
+	}
+	return locale, nil
+}
+
+// loadTheme returns a built-in theme or one defined under themes.<name>,
+// which restyles the roles it lists on top of its base theme
+func loadTheme(name string) (*ui.Theme, error) {
+	if name == "" {
+		name = ui.DefaultTheme
+	}
+	custom, ok := config.AppConfig.Themes[name]
+	if !ok {
+		return ui.LookupTheme(name)
+	}
+
+	overrides := map[string]string{}
+	baseName := ui.DefaultTheme
+	for key, spec := range custom {
+		if key == "base" {
+			baseName = spec
+			continue
+		}
+		overrides[key] = spec
+	}
+	base, err := ui.LookupTheme(baseName)
+	if err != nil {
+		return nil, fmt.Errorf("theme %s: base must be a built-in theme: %w", name, err)
+	}
+	return ui.NewTheme(name, base, overrides)
+}
+
+// userTemplate loads the report template from --template, or else from
+// the configured file. It returns nil when neither is set.
+func userTemplate(cmd *cobra.Command, renderer *ui.Renderer, configured string) (*template.Template, error) {
+	path, _ := cmd.Flags().GetString("template")
+	if path == "" {
+		path = configured
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return renderer.LoadTemplate(path)
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/biferdou/illapaca/climate"
+	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/history"
+	"github.com/biferdou/illapaca/model"
+	"github.com/spf13/cobra"
+)
+
+var climateCmd = &cobra.Command{
+	Use:   "climate [location]",
+	Short: "Show normals, percentiles and records for a day",
+	Long: `Show climate statistics for a calendar day, computed from the local
+history database: the normal temperature, the typical range and the record
+high and low. Days recorded by 'illapaca log' and days fetched with
+'illapaca climate fetch' are both used.
+
+When statistics are available, 'current' and 'forecast' also show how far
+conditions are from normal.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+
+		date, _ := cmd.Flags().GetString("date")
+		if date == "" {
+			date = time.Now().Format("2006-01-02")
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			fmt.Printf("Error: date must be YYYY-MM-DD, got %q\n", date)
+			os.Exit(1)
+		}
+
+		store, err := history.Open(historyPath())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+
+		days, err := store.AllDays(location)
+		if err != nil {
+			fmt.Printf("Error reading history: %v\n", err)
+			os.Exit(1)
+		}
+
+		days = history.MinReadings(days, minReadings(cmd))
+		c := climate.Compute(location, days, climate.DefaultWindow, climate.MinSamples)
+		newRenderer(cmd).DisplayClimate(c, date)
+	},
+}
+
+var climateFetchCmd = &cobra.Command{
+	Use:   "fetch [location]",
+	Short: "Fetch past days from the provider into the history database",
+	Long: `Fetch observed weather for past days from the provider's history API
+and store it in the local history database, skipping days already stored.
+Only WeatherAPI.com offers history, and how far back it reaches depends on
+your plan.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+
+		count, _ := cmd.Flags().GetInt("days")
+
+		client, err := newClient(false)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		store, err := history.Open(historyPath())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+
+		stored, err := store.Days(location)
+		if err != nil {
+			fmt.Printf("Error reading history: %v\n", err)
+			os.Exit(1)
+		}
+		have := map[string]bool{}
+		for _, day := range stored {
+			have[day.Date] = true
+		}
+
+		fetched := 0
+		for i := 1; i <= count; i++ {
+			date := time.Now().AddDate(0, 0, -i).Format("2006-01-02")
+			if have[date] {
+				continue
+			}
+
+			data, err := client.FetchHistoricalWeather(cmd.Context(), location, date)
+			if err != nil {
+				fmt.Printf("Error fetching %s: %v\n", date, err)
+				break
+			}
+			for _, day := range history.HistoryDays(data) {
+				if err := store.RecordDay(client.Provider(), location, day); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				fetched++
+			}
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Stored %d new days for %s\n", fetched, location)
+	},
+}
+
+// minReadings returns --min-readings, or history.min_readings when the flag
+// is not given
+func minReadings(cmd *cobra.Command) int {
+	if cmd.Flags().Changed("min-readings") {
+		n, _ := cmd.Flags().GetInt("min-readings")
+		return n
+	}
+	return config.AppConfig.History.MinReadings
+}
+
+// loadClimate returns climate statistics for location from the history
+// database, or nil when history is off or holds too little data. Today and
+// later, and days with fewer than history.min_readings readings, are left
+// out so that today's readings are compared with a reliable past.
+func loadClimate(location string) *model.Climate {
+	if !config.AppConfig.History.Enabled {
+		return nil
+	}
+
+	store, err := history.Open(historyPath())
+	if err != nil {
+		return nil
+	}
+	defer store.Close()
+
+	days, err := store.AllDays(location)
+	if err != nil {
+		return nil
+	}
+	today := time.Now().Format("2006-01-02")
+	var past []model.ObservedDay
+	for _, day := range history.MinReadings(days, config.AppConfig.History.MinReadings) {
+		if day.Date < today {
+			past = append(past, day)
+		}
+	}
+
+	c := climate.Compute(location, past, climate.DefaultWindow, climate.MinSamples)
+	if len(c.Days) == 0 {
+		return nil
+	}
+	return c
+}
+
+func init() {
+	climateCmd.AddCommand(climateFetchCmd)
+
+	climateCmd.Flags().String("date", "", "Day to show (YYYY-MM-DD, default today)")
+	climateCmd.Flags().Int("min-readings", 0, "Ignore recorded days with fewer readings (default history.min_readings)")
+	climateFetchCmd.Flags().IntP("days", "d", 30, "Number of past days to fetch")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/biferdou/illapaca/ui"
+	"github.com/spf13/cobra"
+)
+
+var commuteCmd = &cobra.Command{
+	Use:   "commute <from> <to>",
+	Short: "Show the weather for each workday's commute between two places",
+	Long: `Show the forecast where you leave and where you arrive for both trips of
+every upcoming workday in the forecast, flagging likely rain and wind at or
+above the alert threshold (see 'illapaca alerts').`,
+	Example: `  illapaca commute Brooklyn Manhattan --depart 08:00 --return 18:00
+  illapaca commute Leeds York --depart 07:15 --return 17:30 --travel 50m`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := args[0], args[1]
+
+		depart, _ := cmd.Flags().GetString("depart")
+		ret, _ := cmd.Flags().GetString("return")
+		travel, _ := cmd.Flags().GetDuration("travel")
+		days, _ := cmd.Flags().GetInt("days")
+
+		commute, err := parseCommute(depart, ret, travel)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		home, err := fetchWeather(cmd.Context(), from, days)
+		if err != nil {
+			fmt.Printf("Error fetching weather for %s: %v\n", from, err)
+			os.Exit(1)
+		}
+		work, err := fetchWeather(cmd.Context(), to, days)
+		if err != nil {
+			fmt.Printf("Error fetching weather for %s: %v\n", to, err)
+			os.Exit(1)
+		}
+
+		newRenderer(cmd).DisplayCommute(home, work, commute)
+	},
+}
+
+// parseCommute reads departure and return times of day as HH:MM
+func parseCommute(depart, ret string, travel time.Duration) (ui.Commute, error) {
+	var c ui.Commute
+	for _, field := range []struct {
+		flag, value string
+		target      *time.Duration
+	}{
+		{"depart", depart, &c.Depart},
+		{"return", ret, &c.Return},
+	} {
+		t, err := time.Parse("15:04", field.value)
+		if err != nil {
+			return c, fmt.Errorf("invalid --%s %q: use a 24-hour time such as 08:00", field.flag, field.value)
+		}
+		*field.target = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if c.Return <= c.Depart {
+		return c, fmt.Errorf("--return %s must be later in the day than --depart %s", ret, depart)
+	}
+	if travel < 0 {
+		return c, fmt.Errorf("--travel cannot be negative")
+	}
+	c.Travel = travel
+	return c, nil
+}
+
+func init() {
+	commuteCmd.Flags().String("depart", "08:00", "Time you leave <from>, as HH:MM")
+	commuteCmd.Flags().String("return", "18:00", "Time you leave <to> to come back, as HH:MM")
+	commuteCmd.Flags().Duration("travel", 30*time.Minute, "Time on the way, each way")
+	commuteCmd.Flags().IntP("days", "d", 5, "Number of forecast days to cover")
+}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/biferdou/illapaca/api"
-	"github.com/biferdou/illapaca/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -19,22 +17,22 @@ var compareCmd = &cobra.Command{
 		location1 := args[0]
 		location2 := args[1]
 
-		// Get weather for first location
-		data1, err := api.FetchWeather(location1, 1)
+		// Two days cover the next 24 hours of trends from any time of day
+		data1, err := fetchWeather(cmd.Context(), location1, 2)
 		if err != nil {
 			fmt.Printf("Error fetching weather for %s: %v\n", location1, err)
 			os.Exit(1)
 		}
 
 		// Get weather for second location
-		data2, err := api.FetchWeather(location2, 1)
+		data2, err := fetchWeather(cmd.Context(), location2, 2)
 		if err != nil {
 			fmt.Printf("Error fetching weather for %s: %v\n", location2, err)
 			os.Exit(1)
 		}
 
 		// Display comparison
-		ui.DisplayLocationComparison(data1, data2)
+		newRenderer(cmd).DisplayLocationComparison(data1, data2)
 	},
 }
 
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/biferdou/illapaca/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config [command]",
+	Short: "View and edit settings",
+	Long: `View and edit Illapaca settings. Available commands:
+  list    - List all settings with their values and sources
+  get     - Show the value of a setting
+  set     - Store a setting in the config file
+  unset   - Remove a setting from the config file
+  path    - Print the config file path
+  edit    - Open the config file in $EDITOR
+  env     - List recognized environment variables
+  profile - Manage named profiles`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings",
+	Run: func(cmd *cobra.Command, args []string) {
+		reveal, _ := cmd.Flags().GetBool("reveal")
+		config.ListSettings(reveal)
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:           "get [key]",
+	Short:         "Show the value of a setting",
+	Args:          cobra.ExactArgs(1),
+	ValidArgs:     config.SettingKeys(),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		setting, err := config.LookupSetting(args[0])
+		if err != nil {
+			return err
+		}
+
+		reveal, _ := cmd.Flags().GetBool("reveal")
+		fmt.Printf("%s (%s)\n", config.DisplayValue(setting, reveal), config.ValueSource(setting.Key))
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:           "set [key] [value]",
+	Short:         "Store a setting in the config file",
+	Args:          cobra.ExactArgs(2),
+	ValidArgs:     config.SettingKeys(),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.SetValue(args[0], args[1]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Set %s in %s\n", args[0], config.ConfigFilePath())
+		return nil
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:           "unset [key]",
+	Short:         "Remove a setting from the config file",
+	Args:          cobra.ExactArgs(1),
+	ValidArgs:     config.SettingKeys(),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.UnsetValue(args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Removed %s from %s\n", args[0], config.ConfigFilePath())
+		return nil
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the config file path",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(config.ConfigFilePath())
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:           "edit",
+	Short:         "Open the config file in $EDITOR",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+		}
+
+		// Allow editors configured with arguments, e.g. "code --wait"
+		parts := strings.Fields(editor)
+		edit := exec.Command(parts[0], append(parts[1:], config.ConfigFilePath())...)
+		edit.Stdin = os.Stdin
+		edit.Stdout = os.Stdout
+		edit.Stderr = os.Stderr
+		if err := edit.Run(); err != nil {
+			return fmt.Errorf("running %s: %w", parts[0], err)
+		}
+		return nil
+	},
+}
+
+var configEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "List recognized environment variables",
+	Long: `List every environment variable Illapaca reads. Each config key maps to
+ILLAPACA_ followed by the key in upper case with dots replaced by
+underscores, e.g. alert_thresholds.high_temp is ILLAPACA_ALERT_THRESHOLDS_HIGH_TEMP.
+
+Precedence, highest first: command line flag, environment variable,
+active profile, top-level config file setting, built-in default.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.ListEnvironmentVariables()
+	},
+}
+
+var configProfileCmd = &cobra.Command{
+	Use:   "profile [command]",
+	Short: "Manage named profiles",
+	Long: `Profiles keep separate providers, API keys, units, favorites, default
+locations and alert thresholds in one config file. Select one per run with
+--profile or ILLAPACA_PROFILE. Available commands:
+  list    - List profiles
+  create  - Create a profile
+  use     - Make a profile current
+  delete  - Delete a profile and its stored API keys`,
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "List profiles",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return config.ListProfiles()
+	},
+}
+
+var configProfileCreateCmd = &cobra.Command{
+	Use:           "create [name]",
+	Short:         "Create a profile",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		copyCurrent, _ := cmd.Flags().GetBool("copy")
+
+		if err := config.CreateProfile(args[0], copyCurrent); err != nil {
+			return err
+		}
+
+		fmt.Printf("Created profile %s\n", args[0])
+		return nil
+	},
+}
+
+var configProfileUseCmd = &cobra.Command{
+	Use:           "use [name]",
+	Short:         "Make a profile current",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.UseProfile(args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Now using profile %s\n", args[0])
+		return nil
+	},
+}
+
+var configProfileDeleteCmd = &cobra.Command{
+	Use:           "delete [name]",
+	Short:         "Delete a profile and its stored API keys",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.DeleteProfile(args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Deleted profile %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configEnvCmd)
+	configCmd.AddCommand(configProfileCmd)
+
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileCreateCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileCmd.AddCommand(configProfileDeleteCmd)
+
+	configProfileCreateCmd.Flags().Bool("copy", false, "Copy settings from the active profile")
+
+	configListCmd.Flags().Bool("reveal", false, "Show secret values such as the API key")
+	configGetCmd.Flags().Bool("reveal", false, "Show secret values such as the API key")
+}
@@ -4,8 +4,7 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/biferdou/illapaca/api"
-	"github.com/biferdou/illapaca/ui"
+	"github.com/biferdou/illapaca/config"
 	"github.com/spf13/cobra"
 )
 
@@ -20,12 +19,34 @@ var currentCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		data, err := api.FetchWeather(location, 1)
+		renderer := newRenderer(cmd)
+		tmpl, err := userTemplate(cmd, renderer, config.AppConfig.Templates.Current)
+		if err != nil {
+			fmt.Printf("Error loading template: %v\n", err)
+			os.Exit(1)
+		}
+
+		// The advice looks 12 hours ahead, which can run into tomorrow
+		data, err := fetchWeather(cmd.Context(), location, 2)
 		if err != nil {
 			fmt.Printf("Error fetching weather: %v\n", err)
 			os.Exit(1)
 		}
 
-		ui.DisplayCurrentWeather(data)
+		if tmpl != nil {
+			if err := renderer.DisplayTemplate(tmpl, data); err != nil {
+				fmt.Printf("Error rendering template: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		renderer.SetClimate(loadClimate(location))
+		renderer.DisplayCurrentWeather(data)
+		renderer.DisplayAdvice(data)
 	},
 }
+
+func init() {
+	currentCmd.Flags().String("template", "", "Render with this Go template file instead (default templates.current)")
+}
@@ -6,8 +6,6 @@ import (
 	"os/signal"
 	"syscall"
 
-	"github.com/biferdou/illapaca/api"
-	"github.com/biferdou/illapaca/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -22,13 +20,19 @@ var dashboardCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		data, err := api.FetchWeather(location, 5)
+		data, err := fetchWeather(cmd.Context(), location, 5)
 		if err != nil {
 			fmt.Printf("Error fetching weather: %v\n", err)
 			os.Exit(1)
 		}
 
-		ui.DisplayDashboard(data)
+		renderer := newRenderer(cmd)
+		if compact, _ := cmd.Flags().GetBool("compact"); compact {
+			renderer.DisplayCompactDashboard(data)
+			return
+		}
+		renderer.SetClimate(loadClimate(location))
+		renderer.DisplayDashboard(data)
 
 		// Listen for Ctrl+C to exit dashboard
 		fmt.Println("Press Ctrl+C to exit dashboard")
@@ -37,3 +41,7 @@ var dashboardCmd = &cobra.Command{
 		<-c
 	},
 }
+
+func init() {
+	dashboardCmd.Flags().Bool("compact", false, "Show a few lines for small terminals and exit")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/export"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [command]",
+	Short: "Export forecasts to other formats",
+	Long: `Export forecasts for use in other programs. Available commands:
+  ical    - iCalendar feed with one all-day event per forecast day`,
+}
+
+var exportICalCmd = &cobra.Command{
+	Use:   "ical [location]",
+	Short: "Export the forecast as an iCalendar (.ics) feed",
+	Long: `Write an iCalendar feed with one all-day event per forecast day. The
+summary shows the condition, high/low and chance of rain; the description
+adds sunrise and sunset. With --alerts, days that cross an alert threshold
+get an extra event.
+
+Regenerate the file on a schedule and publish it where your calendar can
+subscribe to it:
+
+  illapaca export ical Cusco --days 7 --alerts -o /var/www/cusco.ics`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+
+		days, _ := cmd.Flags().GetInt("days")
+		alerts, _ := cmd.Flags().GetBool("alerts")
+		output, _ := cmd.Flags().GetString("output")
+
+		data, err := fetchWeather(cmd.Context(), location, days)
+		if err != nil {
+			fmt.Printf("Error fetching weather: %v\n", err)
+			os.Exit(1)
+		}
+
+		var w io.Writer = cmd.OutOrStdout()
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		err = export.ICal(w, data, export.ICalOptions{
+			Alerts:          alerts,
+			AlertThresholds: config.AppConfig.AlertThresholds,
+		})
+		if err != nil {
+			fmt.Printf("Error writing calendar: %v\n", err)
+			os.Exit(1)
+		}
+		if output != "" {
+			fmt.Printf("Wrote %d forecast days to %s\n", len(data.Forecast.ForecastDay), output)
+		}
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportICalCmd)
+
+	exportICalCmd.Flags().IntP("days", "d", 7, "Number of days to export")
+	exportICalCmd.Flags().Bool("alerts", false, "Add events for days that cross alert thresholds")
+	exportICalCmd.Flags().StringP("output", "o", "", "Write to a file instead of standard output")
+}
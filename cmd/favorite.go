@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 
-	"github.com/biferdou/illapaca/api"
 	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/model"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +15,7 @@ var favoriteCmd = &cobra.Command{
 	Short: "Manage favorite locations",
 	Long: `Manage your favorite locations. Available commands:
   list    - List all favorite locations
+  overview - Show current weather and trends for every favorite
   add     - Add a location to favorites
   remove  - Remove a location from favorites
   set-default - Set a location as default`,
@@ -27,6 +29,25 @@ var favoriteListCmd = &cobra.Command{
 	},
 }
 
+var favoriteOverviewCmd = &cobra.Command{
+	Use:   "overview",
+	Short: "Show current weather and 24-hour trends for every favorite",
+	Run: func(cmd *cobra.Command, args []string) {
+		var locations []*model.WeatherData
+		for _, location := range config.AppConfig.FavoriteLocations {
+			// Two days cover the next 24 hours from any time of day
+			data, err := fetchWeather(cmd.Context(), location, 2)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", location, err)
+				continue
+			}
+			locations = append(locations, data)
+		}
+
+		newRenderer(cmd).DisplayFavorites(locations)
+	},
+}
+
 var favoriteAddCmd = &cobra.Command{
 	Use:   "add [location]",
 	Short: "Add a location to favorites",
@@ -35,7 +56,7 @@ var favoriteAddCmd = &cobra.Command{
 		location := args[0]
 
 		// Verify location by fetching its weather
-		_, err := api.FetchWeather(location, 1)
+		_, err := fetchWeather(cmd.Context(), location, 1)
 		if err != nil {
 			fmt.Printf("Error: invalid location or API error - %v\n", err)
 			return
@@ -92,6 +113,7 @@ var favoriteSetDefaultCmd = &cobra.Command{
 
 func init() {
 	favoriteCmd.AddCommand(favoriteListCmd)
+	favoriteCmd.AddCommand(favoriteOverviewCmd)
 	favoriteCmd.AddCommand(favoriteAddCmd)
 	favoriteCmd.AddCommand(favoriteRemoveCmd)
 	favoriteCmd.AddCommand(favoriteSetDefaultCmd)
@@ -3,8 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
-	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/config"
 	"github.com/biferdou/illapaca/ui"
 	"github.com/spf13/cobra"
 )
@@ -21,18 +22,49 @@ var forecastCmd = &cobra.Command{
 		}
 
 		days, _ := cmd.Flags().GetInt("days")
+		heatmap, _ := cmd.Flags().GetBool("heatmap")
+		metricName, _ := cmd.Flags().GetString("metric")
 
-		data, err := api.FetchWeather(location, days)
+		metric, err := ui.LookupHeatmapMetric(metricName)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		renderer := newRenderer(cmd)
+		tmpl, err := userTemplate(cmd, renderer, config.AppConfig.Templates.Forecast)
+		if err != nil {
+			fmt.Printf("Error loading template: %v\n", err)
+			os.Exit(1)
+		}
+
+		data, err := fetchWeather(cmd.Context(), location, days)
 		if err != nil {
 			fmt.Printf("Error fetching weather: %v\n", err)
 			os.Exit(1)
 		}
 
-		ui.DisplayCurrentWeather(data)
-		ui.DisplayForecast(data)
+		if heatmap {
+			renderer.DisplayHeatmap(data, metric)
+			return
+		}
+		if tmpl != nil {
+			if err := renderer.DisplayTemplate(tmpl, data); err != nil {
+				fmt.Printf("Error rendering template: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		renderer.SetClimate(loadClimate(location))
+		renderer.DisplayCurrentWeather(data)
+		renderer.DisplayForecast(data)
 	},
 }
 
 func init() {
 	forecastCmd.Flags().IntP("days", "d", 5, "Number of days for forecast")
+	forecastCmd.Flags().String("template", "", "Render with this Go template file instead (default templates.forecast)")
+	forecastCmd.Flags().Bool("heatmap", false, "Show a grid of days by hours colored by --metric")
+	forecastCmd.Flags().String("metric", ui.DefaultHeatmapMetric, "Heatmap metric: "+strings.Join(ui.HeatmapMetricNames(), ", "))
 }
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/biferdou/illapaca/history"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [location]",
+	Short: "Show past weather",
+	Long: `Show observed weather for a past date from the provider's history API,
+or with --local, daily summaries from the local history database recorded
+by 'illapaca log' and other commands. Local history needs no paid plan.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+
+		local, _ := cmd.Flags().GetBool("local")
+		if local {
+			days, _ := cmd.Flags().GetInt("days")
+			showLocalHistory(cmd, location, days)
+			return
+		}
+
+		date, _ := cmd.Flags().GetString("date")
+		if date == "" {
+			date = time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			fmt.Printf("Error: date must be YYYY-MM-DD, got %q\n", date)
+			os.Exit(1)
+		}
+
+		client, err := newClient(false)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		data, err := client.FetchHistoricalWeather(cmd.Context(), location, date)
+		if err != nil {
+			fmt.Printf("Error fetching history: %v\n", err)
+			os.Exit(1)
+		}
+
+		newRenderer(cmd).DisplayHistory(data)
+	},
+}
+
+// showLocalHistory prints recorded daily summaries for the last days days
+func showLocalHistory(cmd *cobra.Command, location string, days int) {
+	store, err := history.Open(historyPath())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	from := time.Now().AddDate(0, 0, -days)
+	observations, err := store.Observations(location, from, time.Time{})
+	if err != nil {
+		fmt.Printf("Error reading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	newRenderer(cmd).DisplayObservedDays(location, history.DailySummaries(observations))
+}
+
+func init() {
+	historyCmd.Flags().Bool("local", false, "Use the local history database instead of the provider")
+	historyCmd.Flags().String("date", "", "Date to show from the provider (YYYY-MM-DD, default yesterday)")
+	historyCmd.Flags().IntP("days", "d", 7, "Number of past days to show with --local")
+}
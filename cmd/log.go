@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/history"
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log [location...]",
+	Short: "Record weather for favorite locations in the local history",
+	Long: `Fetch current conditions and forecasts and store them in the local
+history database. Without arguments the favorite locations are recorded,
+or the default location if there are no favorites.
+
+By default the locations are recorded once, which suits cron:
+
+  0 * * * * illapaca log
+
+With --interval the command keeps recording until interrupted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		locations := args
+		if len(locations) == 0 {
+			locations = config.AppConfig.FavoriteLocations
+		}
+		if len(locations) == 0 && config.AppConfig.DefaultLocation != "" {
+			locations = []string{config.AppConfig.DefaultLocation}
+		}
+		if len(locations) == 0 {
+			fmt.Println("Error: no locations to record; add favorites with 'illapaca favorite add'")
+			return
+		}
+
+		interval, _ := cmd.Flags().GetDuration("interval")
+		days, _ := cmd.Flags().GetInt("days")
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		recordLocations(ctx, cmd, locations, days)
+		if interval <= 0 {
+			return
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Recording every %s, press Ctrl+C to stop\n", interval)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				recordLocations(ctx, cmd, locations, days)
+			}
+		}
+	},
+}
+
+// recordLocations fetches each location and stores it in the history,
+// reporting failures without stopping
+func recordLocations(ctx context.Context, cmd *cobra.Command, locations []string, days int) {
+	client, err := newClient(false)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	recorder := history.Recorder{Path: historyPath()}
+
+	for _, location := range locations {
+		data, err := client.FetchWeather(ctx, location, days)
+		if err != nil {
+			fmt.Printf("Error fetching weather for %s: %v\n", location, err)
+			continue
+		}
+		if err := recorder.Record(client.Provider(), location, data); err != nil {
+			fmt.Printf("Error recording %s: %v\n", location, err)
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s  Recorded %s (%.1f°C, %s)\n",
+			time.Now().Format("2006-01-02 15:04"), location, data.Current.TempC, data.Current.Condition.Text)
+	}
+}
+
+func init() {
+	logCmd.Flags().Duration("interval", 0, "Keep recording at this interval, e.g. 1h (default records once)")
+	logCmd.Flags().IntP("days", "d", 3, "Number of forecast days to record")
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/biferdou/illapaca/activity"
+	"github.com/biferdou/illapaca/config"
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan [location]",
+	Short: "Find the best times for an outdoor activity",
+	Long: `Score each forecast hour from 0 to 100 for an activity, weighing
+temperature, feels-like temperature, wind, chance of rain, UV index and
+daylight, and suggest the best windows over the next days.
+
+Built-in activities: ` + strings.Join(activity.Names(), ", ") + `.
+Define your own, or adjust a built-in one, under activities.<name> in the
+config file:
+
+  activities:
+    swimming:
+      base: bbq
+      temp: [22, 32]
+      weights:
+        temp: 3
+        rain: 2`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+
+		name, _ := cmd.Flags().GetString("activity")
+		days, _ := cmd.Flags().GetInt("days")
+		minScore, _ := cmd.Flags().GetInt("min-score")
+
+		profile, err := loadActivity(name)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		data, err := fetchWeather(cmd.Context(), location, days)
+		if err != nil {
+			fmt.Printf("Error fetching weather: %v\n", err)
+			os.Exit(1)
+		}
+
+		newRenderer(cmd).DisplayPlan(data, profile, minScore)
+	},
+}
+
+// loadActivity returns a built-in activity or one defined under
+// activities.<name>, which changes the fields it sets on top of its base
+// activity. The base defaults to the built-in activity of the same name.
+func loadActivity(name string) (*activity.Profile, error) {
+	name = strings.ToLower(name)
+	custom, ok := config.AppConfig.Activities[name]
+	if !ok {
+		return activity.Lookup(name)
+	}
+
+	baseName := custom.Base
+	if baseName == "" {
+		baseName = name
+	}
+	profile, err := activity.Lookup(baseName)
+	if err != nil {
+		return nil, fmt.Errorf("activity %s: base must be a built-in activity: %w", name, err)
+	}
+	profile.Name = name
+
+	ranges := []struct {
+		key    string
+		values []float64
+		target *activity.Range
+	}{
+		{"temp", custom.Temp, &profile.Temp},
+		{"feels_like", custom.FeelsLike, &profile.FeelsLike},
+		{"wind", custom.Wind, &profile.Wind},
+		{"rain", custom.Rain, &profile.Rain},
+		{"uv", custom.UV, &profile.UV},
+	}
+	for _, r := range ranges {
+		if r.values == nil {
+			continue
+		}
+		if len(r.values) != 2 {
+			return nil, fmt.Errorf("activity %s: %s must be a [min, max] pair", name, r.key)
+		}
+		*r.target = activity.Range{Min: r.values[0], Max: r.values[1]}
+	}
+	if custom.Daylight != nil {
+		profile.Daylight = *custom.Daylight
+	}
+	if custom.GoldenHour != nil {
+		profile.GoldenHour = *custom.GoldenHour
+	}
+	for factor, w := range custom.Weights {
+		profile.Weights[factor] = w
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func init() {
+	planCmd.Flags().StringP("activity", "a", "hiking", "Activity to plan: "+strings.Join(activity.Names(), ", ")+" or one under activities")
+	planCmd.Flags().IntP("days", "d", 3, "Number of forecast days to search")
+	planCmd.Flags().Int("min-score", activity.DefaultMinScore, "Lowest score, 0 to 100, of an hour in a suggested window")
+}
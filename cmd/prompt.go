@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// refreshLockAge is how long a refresh may run before another prompt
+// assumes it died and starts a new one
+const refreshLockAge = time.Minute
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt [location]",
+	Short: "Print a one-line weather summary for shell prompts and status bars",
+	Long: `Print a one-line weather summary for tmux, starship, waybar or i3bar.
+
+The summary is always read from a local cache, so prompt returns in a few
+milliseconds and never waits for the network. When the cached weather is
+older than --ttl, a background process refreshes it for the next call. Until
+the first refresh completes, "…" is printed.
+
+--format is a Go template over the weather data, with these shortcuts:
+
+  .Icon .Condition .Temp .FeelsLike     e.g. ☀️ Sunny 16°C 15°C
+  .TempC .TempF .FeelsLikeC .FeelsLikeF .Humidity .WindKph .RainChance
+  .Alerts                               alert messages for today
+
+and the full model under .Location, .Current and .Forecast. The template
+helpers of 'current --template' (icon, temp, color, sparkline, ...) are
+available too.
+
+Presets shape the output for a status bar:
+
+  plain    the formatted text (default)
+  tmux     text colored with #[fg=...] by temperature or active alerts
+  i3bar    an i3bar JSON block with full_text, short_text and color
+  waybar   a waybar JSON object with text, tooltip, class and percentage
+
+Examples:
+  illapaca prompt Cusco --format '{{.Icon}} {{.TempC}}°'
+  set -g status-right '#(illapaca prompt --preset tmux)'
+  "exec": "illapaca prompt --preset waybar", "return-type": "json"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		preset, _ := cmd.Flags().GetString("preset")
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+		renderer := newRenderer(cmd)
+		tmpl, err := renderer.ParsePromptFormat(format)
+		if err != nil {
+			fmt.Printf("Error: invalid --format: %v\n", err)
+			os.Exit(1)
+		}
+
+		cache := illapaca.NewFileCache(promptCacheDir(), ttl)
+		locale, _ := loadLocale()
+		key := illapaca.LanguageCacheKey(config.AppConfig.Provider, locale.ProviderLang, location, 1)
+
+		if refresh {
+			refreshPrompt(cmd, cache, key, location)
+			return
+		}
+
+		data, fetchedAt, ok := cache.Load(key)
+		if !ok || time.Since(fetchedAt) > ttl {
+			startPromptRefresh(cmd, cache.Path(key)+".lock", location)
+		}
+
+		if err := renderer.DisplayPrompt(data, tmpl, preset); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// promptCacheDir returns the directory shared by prompt processes
+func promptCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "illapaca", "prompt")
+}
+
+// startPromptRefresh runs 'prompt --refresh' in the background unless one
+// is already running for this location. The lock file is removed by the
+// refresh when it finishes.
+func startPromptRefresh(cmd *cobra.Command, lock, location string) {
+	if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) < refreshLockAge {
+		return
+	}
+	os.Remove(lock)
+
+	if err := os.MkdirAll(filepath.Dir(lock), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		// Another prompt won the race
+		return
+	}
+	f.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		os.Remove(lock)
+		return
+	}
+
+	// Pass on global flags such as --config and --profile so the refresh
+	// fetches with the same settings. The API key goes through the
+	// environment to keep it out of the process list.
+	args := []string{"prompt", "--refresh", "--ttl", cmd.Flag("ttl").Value.String()}
+	env := os.Environ()
+	cmd.InheritedFlags().Visit(func(f *pflag.Flag) {
+		if f.Name == "api-key" {
+			env = append(env, config.EnvVarName("api_key")+"="+f.Value.String())
+			return
+		}
+		args = append(args, "--"+f.Name+"="+f.Value.String())
+	})
+	args = append(args, location)
+
+	child := exec.Command(exe, args...)
+	child.Env = env
+	if err := child.Start(); err != nil {
+		os.Remove(lock)
+		return
+	}
+	child.Process.Release()
+}
+
+// refreshPrompt fetches location into the prompt cache. Errors are silent:
+// the prompt keeps showing the last weather it has.
+func refreshPrompt(cmd *cobra.Command, cache *illapaca.FileCache, key, location string) {
+	defer os.Remove(cache.Path(key) + ".lock")
+
+	client, err := newClient(true)
+	if err != nil {
+		return
+	}
+	data, err := client.FetchWeather(cmd.Context(), location, 1)
+	if err != nil {
+		return
+	}
+	cache.Set(key, data)
+}
+
+func init() {
+	promptCmd.Flags().String("format", "", "Go template for the summary (default \""+ui.DefaultPromptFormat+"\")")
+	promptCmd.Flags().String("preset", "plain", "Output preset: "+strings.Join(ui.PromptPresets, ", "))
+	promptCmd.Flags().Duration("ttl", 10*time.Minute, "Refresh cached weather older than this")
+	promptCmd.Flags().Bool("refresh", false, "Fetch into the prompt cache and exit (used internally)")
+	promptCmd.Flags().MarkHidden("refresh")
+}
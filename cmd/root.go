@@ -23,9 +23,14 @@ func Execute() error {
 func init() {
 	cobra.OnInitialize(config.InitConfig)
 
-	rootCmd.PersistentFlags().StringVar(&config.CfgFile, "config", "", "config file (default is $HOME/.illapa.yaml)")
+	rootCmd.PersistentFlags().StringVar(&config.CfgFile, "config", "", "config file (default is $HOME/.illapaca.yaml)")
+	rootCmd.PersistentFlags().StringVar(&config.ProfileName, "profile", "", "config profile to use (default is $ILLAPACA_PROFILE or the current profile)")
 	rootCmd.PersistentFlags().String("api-key", "", "API key for weather service")
 	rootCmd.PersistentFlags().String("units", "metric", "Units to display (metric or imperial)")
+	rootCmd.PersistentFlags().String("provider-url", "", "Override the weather provider server URL (e.g. a local fake server)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().Bool("accessible", false, "Screen reader friendly output: text summaries instead of charts, icons and tables")
+	rootCmd.PersistentFlags().String("lang", "", "Output language: en, es, fr, de or qu (default from LANG)")
 
 	config.BindFlags(rootCmd)
 
@@ -36,6 +41,18 @@ func init() {
 	rootCmd.AddCommand(favoriteCmd)
 	rootCmd.AddCommand(alertsCmd)
 	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(commuteCmd)
+	rootCmd.AddCommand(windCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(accuracyCmd)
+	rootCmd.AddCommand(climateCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(promptCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/config"
+)
+
+// run executes the CLI against the fake provider server and returns stdout
+func run(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := execute(t, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// execute runs the CLI like run, returning the command's error instead of
+// failing the test
+func execute(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	server := apitest.NewServer()
+	t.Cleanup(server.Close)
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ILLAPACA_API_KEY", apitest.APIKey)
+	t.Setenv("NO_COLOR", "1")
+
+	config.CfgFile = home + "/.illapaca.yaml"
+	t.Cleanup(func() {
+		config.CfgFile = ""
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs(append(args, "--provider-url", server.URL))
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"config", "get", "no_such_key"},
+		{"config", "set", "no_such_key", "1"},
+		{"config", "unset", "no_such_key"},
+	} {
+		if _, err := execute(t, args...); err == nil {
+			t.Errorf("%v: want an error for an invalid key", args)
+		}
+	}
+}
+
+func TestConfigEditFails(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "illapaca-no-such-editor")
+	if _, err := execute(t, "config", "edit"); err == nil {
+		t.Error("want an error when the editor cannot run")
+	}
+}
+
+func TestCurrentCommand(t *testing.T) {
+	out := run(t, "current", apitest.LocationOK)
+
+	for _, want := range []string{"London, United Kingdom", "Cloudy", "16.2°C", "Comfort and Advice", "Umbrella:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestForecastCommand(t *testing.T) {
+	out := run(t, "forecast", apitest.LocationOK, "--days", "2")
+
+	if !strings.Contains(out, "2025-10-18") {
+		t.Errorf("output missing second day:\n%s", out)
+	}
+	if strings.Contains(out, "2025-10-19") {
+		t.Errorf("output has more than 2 days:\n%s", out)
+	}
+}
+
+func TestWindCommand(t *testing.T) {
+	out := run(t, "wind", apitest.LocationOK)
+	for _, want := range []string{"Beaufort 3: Gentle breeze", "Sustained Wind and Gusts", "Wind Rose", "Mostly from the"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestForecastHeatmap(t *testing.T) {
+	out := run(t, "forecast", apitest.LocationOK, "--heatmap", "--metric", "rain", "--days", "3")
+	for _, want := range []string{"Chance of Rain by Hour", "00    03    06", "0% ··░░▒▒▓▓██ 100%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCommuteCommand(t *testing.T) {
+	out := run(t, "commute", apitest.LocationOK, apitest.LocationOK, "--depart", "07:30", "--return", "17:00")
+	for _, want := range []string{"Commute: London to London", "returning at 17:00", "17:00", "17:30"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseCommute(t *testing.T) {
+	c, err := parseCommute("07:45", "17:15", 40*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Depart != 7*time.Hour+45*time.Minute || c.Return != 17*time.Hour+15*time.Minute || c.Travel != 40*time.Minute {
+		t.Errorf("parseCommute = %+v", c)
+	}
+	for _, args := range [][2]string{{"8am", "18:00"}, {"08:00", "25:00"}, {"18:00", "08:00"}} {
+		if _, err := parseCommute(args[0], args[1], 0); err == nil {
+			t.Errorf("parseCommute(%q, %q): want an error", args[0], args[1])
+		}
+	}
+}
+
+func TestPlanCommand(t *testing.T) {
+	out := run(t, "plan", apitest.LocationOK, "--activity", "running")
+	for _, want := range []string{"Best Times for Running", "1. Oct 17 14:00–18:00", "Hourly Scores"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestLoadActivity(t *testing.T) {
+	daylight := false
+	config.AppConfig.Activities = map[string]config.ActivityConfig{
+		"swimming": {Base: "bbq", Temp: []float64{22, 32}, Weights: map[string]float64{"temp": 3}},
+		"running":  {Daylight: &daylight},
+		"broken":   {Base: "hiking", Wind: []float64{10}},
+		"orphan":   {Temp: []float64{0, 10}},
+	}
+	t.Cleanup(func() { config.AppConfig.Activities = nil })
+
+	swimming, err := loadActivity("swimming")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swimming.Temp.Min != 22 || swimming.Weights["temp"] != 3 || swimming.Weights["rain"] != 4 {
+		t.Errorf("swimming = %+v, want bbq with temp 22-32 weighted 3", swimming)
+	}
+
+	running, err := loadActivity("running")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if running.Daylight || running.Temp.Max != 18 {
+		t.Errorf("running = %+v, want the built-in without daylight", running)
+	}
+
+	for _, name := range []string{"broken", "orphan", "knitting"} {
+		if _, err := loadActivity(name); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
+
+func TestFavoriteOverview(t *testing.T) {
+	t.Setenv("ILLAPACA_FAVORITE_LOCATIONS", apitest.LocationOK)
+	out := run(t, "favorite", "overview")
+	for _, want := range []string{"Favorite Locations", "TEMP (24H)", "▁"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
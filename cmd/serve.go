@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/history"
+	"github.com/biferdou/illapaca/server"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [location...]",
+	Short: "Run as a service publishing weather over HTTP",
+	Long: `Keep the weather fresh and publish it over HTTP, so other programs
+need no API key of their own.
+
+  --metrics :9090    Prometheus metrics at /metrics for a set of locations
+  --http :8080       JSON API at /v1/current, /v1/forecast, /v1/compare and
+                     /v1/alerts for any location (?q=Cusco)
+
+Metrics cover the given locations, or else the favorite locations and the
+default location, refreshed every --interval. Every response is cached for
+the same period, keeping the 1000 most recently used, and identical API
+requests in flight are merged, so callers never cost extra provider
+requests. With --token (or serve.token in the config), API callers must
+send "Authorization: Bearer <token>".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		metricsAddr, _ := cmd.Flags().GetString("metrics")
+		httpAddr, _ := cmd.Flags().GetString("http")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = config.AppConfig.ServeToken
+		}
+
+		if metricsAddr == "" && httpAddr == "" {
+			fmt.Println("Error: nothing to serve; use --metrics and/or --http")
+			os.Exit(1)
+		}
+		if interval < time.Minute {
+			fmt.Println("Error: --interval must be at least 1m to respect API quotas")
+			os.Exit(1)
+		}
+
+		cache := illapaca.NewBoundedMemoryCache(interval, serveCacheEntries)
+		client, err := newServiceClient(cache)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go sweepCache(ctx, cache, interval)
+
+		// Both endpoints may share one address
+		muxes := map[string]*http.ServeMux{}
+		muxFor := func(addr string) *http.ServeMux {
+			if muxes[addr] == nil {
+				muxes[addr] = http.NewServeMux()
+			}
+			return muxes[addr]
+		}
+
+		if metricsAddr != "" {
+			locations := serveLocations(args)
+			if len(locations) == 0 {
+				fmt.Println("Error: no locations for metrics; add favorites with 'illapaca favorite add'")
+				os.Exit(1)
+			}
+
+			poller := server.NewPoller(client, locations, 3, interval)
+			go poller.Run(ctx)
+
+			muxFor(metricsAddr).Handle("/metrics", server.MetricsHandler(poller, config.AppConfig.AlertThresholds))
+			fmt.Fprintf(cmd.OutOrStdout(), "Serving metrics for %d locations on %s/metrics (refresh every %s)\n",
+				len(locations), metricsAddr, interval)
+		}
+
+		if httpAddr != "" {
+			muxFor(httpAddr).Handle("/v1/", server.APIHandler(client, server.APIOptions{
+				Token:           token,
+				AlertThresholds: config.AppConfig.AlertThresholds,
+			}))
+			auth := "no authentication"
+			if token != "" {
+				auth = "bearer token required"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Serving JSON API on %s/v1 (%s)\n", httpAddr, auth)
+		}
+
+		errs := make(chan error, len(muxes))
+		for addr, mux := range muxes {
+			go func() {
+				errs <- listen(ctx, addr, mux)
+			}()
+		}
+		for range muxes {
+			if err := <-errs; err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+// serveLocations returns args, or else the favorites plus the default
+// location without duplicates
+func serveLocations(args []string) []string {
+	if len(args) > 0 {
+		return args
+	}
+
+	var locations []string
+	seen := map[string]bool{}
+	for _, location := range append(config.AppConfig.FavoriteLocations, config.AppConfig.DefaultLocation) {
+		if location != "" && !seen[location] {
+			seen[location] = true
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+// serveCacheEntries bounds the responses kept for the JSON API, whose
+// callers may ask for any location
+const serveCacheEntries = 1000
+
+// sweepCache drops expired responses every interval until ctx is done
+func sweepCache(ctx context.Context, cache *illapaca.MemoryCache, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cache.Sweep()
+		}
+	}
+}
+
+// newServiceClient builds a client that caches responses in cache and
+// records them in the history database when enabled
+func newServiceClient(cache illapaca.Cache) (*illapaca.Client, error) {
+	key, err := config.ResolveAPIKey(config.AppConfig.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []illapaca.Option{
+		illapaca.WithProvider(config.AppConfig.Provider, key),
+		illapaca.WithUnits(config.AppConfig.Units),
+		illapaca.WithBaseURL(config.AppConfig.ProviderURL),
+		illapaca.WithHTTPClient(&http.Client{Timeout: 30 * time.Second}),
+		illapaca.WithCache(cache),
+	}
+	if config.AppConfig.History.Enabled {
+		opts = append(opts, illapaca.WithRecorder(history.Recorder{Path: historyPath()}))
+	}
+	return illapaca.New(opts...)
+}
+
+// listen serves handler on addr until ctx is done
+func listen(ctx context.Context, addr string, handler http.Handler) error {
+	srv := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	serveCmd.Flags().String("metrics", "", "Serve Prometheus metrics on this address, e.g. :9090")
+	serveCmd.Flags().String("http", "", "Serve the JSON API on this address, e.g. :8080")
+	serveCmd.Flags().String("token", "", "Bearer token required by the JSON API (default serve.token)")
+	serveCmd.Flags().Duration("interval", 10*time.Minute, "How often to refresh each location")
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var windCmd = &cobra.Command{
+	Use:   "wind [location]",
+	Short: "Show current wind, gusts over the next 24 hours and a wind rose",
+	Long: `Show a wind panel: the current wind on a compass with its Beaufort force,
+sustained wind and gusts every three hours over the next 24 hours, and a
+wind rose of the share of today's hours the wind blows from each direction.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		location := getLocation(args)
+		if location == "" {
+			fmt.Println("Error: location not specified and no default location set")
+			os.Exit(1)
+		}
+
+		// Two days cover the next 24 hours from any time of day
+		data, err := fetchWeather(cmd.Context(), location, 2)
+		if err != nil {
+			fmt.Printf("Error fetching weather: %v\n", err)
+			os.Exit(1)
+		}
+
+		newRenderer(cmd).DisplayWind(data)
+	},
+}
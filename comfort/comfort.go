@@ -0,0 +1,82 @@
+// Package comfort derives how the weather feels from temperature, humidity
+// and wind: dew point, heat index, wind chill and humidex.
+package comfort
+
+import (
+	"math"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// Metrics are the comfort measures of a moment's weather, in °C
+type Metrics struct {
+	DewPointC  float64
+	HeatIndexC float64
+	WindChillC float64
+	HumidexC   float64
+}
+
+// Compute derives the comfort metrics of the current weather
+func Compute(current model.CurrentWeather) Metrics {
+	return Metrics{
+		DewPointC:  DewPoint(current.TempC, current.Humidity),
+		HeatIndexC: HeatIndex(current.TempC, current.Humidity),
+		WindChillC: WindChill(current.TempC, current.WindKph),
+		HumidexC:   Humidex(current.TempC, current.Humidity),
+	}
+}
+
+// DewPoint returns the temperature at which air at tempC and a relative
+// humidity in percent would saturate, by the Magnus formula
+func DewPoint(tempC float64, humidity int) float64 {
+	const b, c = 17.62, 243.12
+	rh := math.Min(math.Max(float64(humidity), 1), 100)
+	gamma := math.Log(rh/100) + b*tempC/(c+tempC)
+	return c * gamma / (b - gamma)
+}
+
+// HeatIndex returns how hot humid air feels, by the US National Weather
+// Service's regression. It is the air temperature below 80°F (26.7°C),
+// where the index does not apply.
+func HeatIndex(tempC float64, humidity int) float64 {
+	t := tempC*9/5 + 32
+	rh := float64(humidity)
+	if t < 80 {
+		return tempC
+	}
+
+	// The simple formula is good enough while it stays below 80°F
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+			0.00683783*t*t - 0.05481717*rh*rh + 0.00122874*t*t*rh +
+			0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+		switch {
+		case rh < 13 && t >= 80 && t <= 112:
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		case rh > 85 && t >= 80 && t <= 87:
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return (hi - 32) * 5 / 9
+}
+
+// WindChill returns how cold moving air feels, by the formula of
+// Environment Canada and the US National Weather Service. It is the air
+// temperature above 10°C or in winds under 4.8 km/h, where the formula
+// does not apply.
+func WindChill(tempC, windKph float64) float64 {
+	if tempC > 10 || windKph < 4.8 {
+		return tempC
+	}
+	v := math.Pow(windKph, 0.16)
+	return 13.12 + 0.6215*tempC - 11.37*v + 0.3965*tempC*v
+}
+
+// Humidex returns the Canadian measure of how hot humid air feels. It is
+// never below the air temperature.
+func Humidex(tempC float64, humidity int) float64 {
+	dewK := DewPoint(tempC, humidity) + 273.15
+	vapour := 6.11 * math.Exp(5417.7530*(1/273.16-1/dewK))
+	return tempC + math.Max(0, 0.5555*(vapour-10))
+}
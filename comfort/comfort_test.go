@@ -0,0 +1,38 @@
+package comfort
+
+import (
+	"math"
+	"testing"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+func TestMetrics(t *testing.T) {
+	// Expected values from published tables, to the nearest degree
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"dew point 20°C 50%", DewPoint(20, 50), 9.3},
+		{"dew point saturated", DewPoint(15, 100), 15},
+		{"heat index 90°F 70%", HeatIndex(32.2, 70), 41.1},
+		{"heat index mild", HeatIndex(16, 60), 16},
+		{"wind chill -10°C 20 km/h", WindChill(-10, 20), -17.9},
+		{"wind chill calm", WindChill(-10, 2), -10},
+		{"wind chill warm", WindChill(15, 40), 15},
+		{"humidex 30°C 70%", Humidex(30, 70), 41},
+		{"humidex dry", Humidex(10, 20), 10},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1 {
+			t.Errorf("%s = %.1f, want %.1f", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestCompute(t *testing.T) {
+	m := Compute(model.CurrentWeather{TempC: 5, Humidity: 80, WindKph: 30})
+	if m.WindChillC >= 5 || m.HumidexC != 5 || m.DewPointC >= 5 {
+		t.Errorf("Compute = %+v, want wind chill and dew point below 5°C and humidex 5°C", m)
+	}
+}
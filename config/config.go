@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -15,27 +17,63 @@ const Version = "1.0.0"
 
 // Config variables
 var (
-	CfgFile   string
-	AppConfig Config
+	CfgFile     string
+	ProfileName string
+	AppConfig   Config
 )
 
 // Config struct for app configuration
 type Config struct {
+	Profile           string
+	Provider          string
 	APIKey            string
+	ProviderURL       string
 	DefaultLocation   string
 	Units             string
 	FavoriteLocations []string
 	AlertThresholds   AlertThresholds
+	History           HistoryConfig
+	ServeToken        string
+	Templates         TemplatesConfig
+	Theme             string
+	Icons             string
+	Themes            map[string]map[string]string
+	Activities        map[string]ActivityConfig
+	NoColor           bool
+	Accessible        bool
+	Lang              string
 }
 
-// AlertThresholds for weather alerts
-type AlertThresholds struct {
-	HighTemp      float64
-	LowTemp       float64
-	Precipitation float64
-	WindSpeed     float64
+// TemplatesConfig names user template files that replace built-in reports
+type TemplatesConfig struct {
+	Current  string
+	Forecast string
+}
+
+// ActivityConfig is an activity profile defined under activities.<name>.
+// Unset fields keep the values of the built-in activity named by Base.
+type ActivityConfig struct {
+	Base       string
+	Temp       []float64
+	FeelsLike  []float64 `mapstructure:"feels_like"`
+	Wind       []float64
+	Rain       []float64
+	UV         []float64
+	Daylight   *bool
+	GoldenHour *bool `mapstructure:"golden_hour"`
+	Weights    map[string]float64
+}
+
+// HistoryConfig controls the local history database
+type HistoryConfig struct {
+	Enabled     bool
+	Path        string
+	MinReadings int
 }
 
+// AlertThresholds for weather alerts
+type AlertThresholds = model.AlertThresholds
+
 // InitConfig initializes the configuration
 func InitConfig() {
 	if CfgFile != "" {
@@ -49,38 +87,44 @@ func InitConfig() {
 		viper.SetConfigName(".illapaca")
 	}
 
-	viper.SetEnvPrefix("ILLAPACA")
-	viper.AutomaticEnv()
+	// Map every key to ILLAPACA_<KEY> (see EnvVarName)
+	bindEnv()
 
 	// Load .env file if it exists
 	godotenv.Load()
+	warnDeprecatedEnv()
 
 	// Set default values
+	viper.SetDefault("provider", api.ProviderWeatherAPI)
 	viper.SetDefault("units", "metric") // OpenWeatherMap supports metric, imperial, standard
 	viper.SetDefault("favorite_locations", []string{})
-	viper.SetDefault("alert_thresholds", map[string]float64{
-		"high_temp":     35.0,
-		"low_temp":      0.0,
-		"precipitation": 70.0,
-		"wind_speed":    30.0,
-	})
+	viper.SetDefault("alert_thresholds.high_temp", 35.0)
+	viper.SetDefault("alert_thresholds.low_temp", 0.0)
+	viper.SetDefault("alert_thresholds.precipitation", 70.0)
+	viper.SetDefault("alert_thresholds.wind_speed", 30.0)
+	viper.SetDefault("history.enabled", true)
+	viper.SetDefault("history.min_readings", 4)
+	viper.SetDefault("theme", "default")
+	viper.SetDefault("icons", "emoji")
 
 	if err := viper.ReadInConfig(); err != nil {
 		// Config file not found; create a default one
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			fmt.Println("Creating default config file...")
-			// Set default values
-			viper.Set("api_key", "")
-			viper.Set("default_location", "")
-			viper.Set("units", "metric")
-			viper.Set("favorite_locations", []string{})
-			viper.Set("alert_thresholds.high_temp", 30.0)
-			viper.Set("alert_thresholds.low_temp", 0.0)
-			viper.Set("alert_thresholds.precipitation", 70.0)
-			viper.Set("alert_thresholds.wind_speed", 30.0)
+			// Write defaults through a separate instance so that flag and
+			// environment values (such as the API key) are not persisted
+			defaults := viper.New()
+			defaults.SetConfigType("yaml")
+			defaults.Set("default_location", "")
+			defaults.Set("units", "metric")
+			defaults.Set("favorite_locations", []string{})
+			defaults.Set("alert_thresholds.high_temp", 30.0)
+			defaults.Set("alert_thresholds.low_temp", 0.0)
+			defaults.Set("alert_thresholds.precipitation", 70.0)
+			defaults.Set("alert_thresholds.wind_speed", 30.0)
 
 			// Save the config file
-			err = viper.SafeWriteConfig()
+			err = defaults.SafeWriteConfigAs(ConfigFilePath())
 			if err != nil {
 				fmt.Println("Error creating config file:", err)
 			}
@@ -89,9 +133,20 @@ func InitConfig() {
 		}
 	}
 
+	// Layer the active profile over the top-level settings. An unknown
+	// profile must not lock out the commands that repair it.
+	profile := ActiveProfile()
+	if err := applyProfile(profile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using the %s profile\n", err, DefaultProfile)
+		profile = DefaultProfile
+	}
+
 	// Parse config
 	AppConfig = Config{
+		Profile:           profile,
+		Provider:          viper.GetString("provider"),
 		APIKey:            viper.GetString("api_key"),
+		ProviderURL:       viper.GetString("provider_url"),
 		DefaultLocation:   viper.GetString("default_location"),
 		Units:             viper.GetString("units"),
 		FavoriteLocations: viper.GetStringSlice("favorite_locations"),
@@ -101,12 +156,49 @@ func InitConfig() {
 			Precipitation: viper.GetFloat64("alert_thresholds.precipitation"),
 			WindSpeed:     viper.GetFloat64("alert_thresholds.wind_speed"),
 		},
+		History: HistoryConfig{
+			Enabled:     viper.GetBool("history.enabled"),
+			Path:        viper.GetString("history.path"),
+			MinReadings: viper.GetInt("history.min_readings"),
+		},
+		ServeToken: viper.GetString("serve.token"),
+		Templates: TemplatesConfig{
+			Current:  viper.GetString("templates.current"),
+			Forecast: viper.GetString("templates.forecast"),
+		},
+		Theme:      viper.GetString("theme"),
+		Icons:      viper.GetString("icons"),
+		Themes:     customThemes(),
+		Activities: customActivities(),
+		NoColor:    viper.GetBool("no_color"),
+		Accessible: viper.GetBool("accessible"),
+		Lang:       viper.GetString("lang"),
+	}
+}
+
+// customThemes reads the themes defined under themes.<name>, each a map of
+// role names (and optionally base) to styles
+func customThemes() map[string]map[string]string {
+	themes := map[string]map[string]string{}
+	for name := range viper.GetStringMap("themes") {
+		themes[name] = viper.GetStringMapString("themes." + name)
 	}
+	return themes
+}
 
-	// Override with environment variables if they exist
-	if os.Getenv("WEATHER_API_KEY") != "" {
-		AppConfig.APIKey = os.Getenv("WEATHER_API_KEY")
+// customActivities reads the activity profiles defined under
+// activities.<name>, skipping with a warning any that cannot be read
+func customActivities() map[string]ActivityConfig {
+	activities := map[string]ActivityConfig{}
+	for name := range viper.GetStringMap("activities") {
+		var activity ActivityConfig
+		if err := viper.UnmarshalKey("activities."+name, &activity); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring activity %s: %v\n", name, err)
+			continue
+		}
+		activities[name] = activity
 	}
+	return activities
 }
 
 // ShowAlertThresholds displays current alert thresholds
@@ -120,6 +212,14 @@ func ShowAlertThresholds() {
 
 // BindFlags binds command flags to viper
 func BindFlags(cmd *cobra.Command) {
-	viper.BindPFlag("api_key", cmd.PersistentFlags().Lookup("api-key"))
-	viper.BindPFlag("units", cmd.PersistentFlags().Lookup("units"))
+	boundFlags["api_key"] = cmd.PersistentFlags().Lookup("api-key")
+	boundFlags["units"] = cmd.PersistentFlags().Lookup("units")
+	boundFlags["provider_url"] = cmd.PersistentFlags().Lookup("provider-url")
+	boundFlags["no_color"] = cmd.PersistentFlags().Lookup("no-color")
+	boundFlags["accessible"] = cmd.PersistentFlags().Lookup("accessible")
+	boundFlags["lang"] = cmd.PersistentFlags().Lookup("lang")
+
+	for key, flag := range boundFlags {
+		viper.BindPFlag(key, flag)
+	}
 }
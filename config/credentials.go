@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/keystore"
+)
+
+// configuredKey reports whether the api_key given by flag, environment or
+// config file belongs to provider in the active profile. A top-level key in
+// the config file belongs to the top-level provider, so a profile that
+// switches provider without its own api_key does not inherit it.
+func configuredKey(provider string) bool {
+	if provider != AppConfig.Provider || AppConfig.APIKey == "" {
+		return false
+	}
+	if source := ValueSource("api_key"); source == SourceFlag || source == SourceEnv {
+		return true
+	}
+
+	v, err := readConfigFile()
+	if err != nil {
+		return false
+	}
+	if v.IsSet(fileKey("api_key")) {
+		return true
+	}
+	v.SetDefault("provider", api.ProviderWeatherAPI)
+	return v.GetString("provider") == provider
+}
+
+// ResolveAPIKey returns the key for provider in the active profile. A key
+// given by flag, environment or config file for the configured provider
+// wins over one held in the key store.
+func ResolveAPIKey(provider string) (string, error) {
+	if configuredKey(provider) {
+		return AppConfig.APIKey, nil
+	}
+
+	key, _, err := keystore.Lookup(keystoreAccount(provider))
+	if errors.Is(err, keystore.ErrNotFound) {
+		return "", fmt.Errorf("API key not set. Run 'illapaca auth login', use --api-key flag or set ILLAPACA_API_KEY environment variable")
+	}
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// APIKeySource describes where the key for provider would be read from
+func APIKeySource(provider string) string {
+	if configuredKey(provider) {
+		return ValueSource("api_key")
+	}
+
+	_, store, err := keystore.Lookup(keystoreAccount(provider))
+	if err != nil {
+		return "not set"
+	}
+	return store.Name()
+}
+
+// HasPlaintextAPIKey reports whether the config file holds an API key
+func HasPlaintextAPIKey() bool {
+	v, err := readConfigFile()
+	if err != nil {
+		return false
+	}
+	return v.GetString(fileKey("api_key")) != ""
+}
+
+// RemovePlaintextAPIKey deletes api_key from the active profile in the
+// config file if present
+func RemovePlaintextAPIKey() (bool, error) {
+	if !HasPlaintextAPIKey() {
+		return false, nil
+	}
+
+	if err := UnsetValue("api_key"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// StoreAPIKey saves key for provider in the active profile
+func StoreAPIKey(store keystore.Store, provider, key string) error {
+	return store.Set(keystoreAccount(provider), key)
+}
+
+// DeleteAPIKey removes the stored key for provider in the active profile
+func DeleteAPIKey(provider string) (keystore.Store, error) {
+	_, store, err := keystore.Lookup(keystoreAccount(provider))
+	if err != nil {
+		return nil, err
+	}
+	return store, store.Delete(keystoreAccount(provider))
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+const credentialsConfigFile = `
+provider: weatherapi
+api_key: top-level-key
+profiles:
+  owm:
+    provider: openweathermap
+  owm-keyed:
+    provider: openweathermap
+    api_key: profile-key
+  weekend:
+    units: imperial
+`
+
+func TestResolveAPIKeyScope(t *testing.T) {
+	keyring.MockInit()
+
+	tests := []struct {
+		name     string
+		profile  string
+		env      map[string]string
+		provider string
+		wantKey  string
+		wantErr  bool
+	}{
+		{name: "top-level", profile: "default", provider: "weatherapi", wantKey: "top-level-key"},
+		{name: "profile keeps provider", profile: "weekend", provider: "weatherapi", wantKey: "top-level-key"},
+		{name: "profile switches provider", profile: "owm", provider: "openweathermap", wantErr: true},
+		{name: "profile with own key", profile: "owm-keyed", provider: "openweathermap", wantKey: "profile-key"},
+		{name: "env for switched provider", profile: "owm", env: map[string]string{"ILLAPACA_API_KEY": "env-key"}, provider: "openweathermap", wantKey: "env-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{ProfileEnv: tt.profile}
+			for name, value := range tt.env {
+				env[name] = value
+			}
+			loadConfig(t, credentialsConfigFile, env, nil)
+
+			key, err := ResolveAPIKey(tt.provider)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ResolveAPIKey(%q) = %q, want an error", tt.provider, key)
+				}
+				if got := APIKeySource(tt.provider); got != "not set" {
+					t.Errorf("APIKeySource(%q) = %q, want not set", tt.provider, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveAPIKey(%q): %v", tt.provider, err)
+			}
+			if key != tt.wantKey {
+				t.Errorf("ResolveAPIKey(%q) = %q, want %q", tt.provider, key, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestResolveAPIKeyFromKeyStore(t *testing.T) {
+	keyring.MockInit()
+	loadConfig(t, credentialsConfigFile, map[string]string{ProfileEnv: "owm"}, nil)
+
+	if err := keyring.Set("illapaca", "owm/openweathermap", "stored-key"); err != nil {
+		t.Fatal(err)
+	}
+	key, err := ResolveAPIKey("openweathermap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "stored-key" {
+		t.Errorf("ResolveAPIKey = %q, want the key stored for the profile", key)
+	}
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/biferdou/illapaca/keystore"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is prepended to every environment variable Illapaca reads
+const EnvPrefix = "ILLAPACA"
+
+// deprecatedEnv maps old variable names to the key they still set
+var deprecatedEnv = map[string]string{
+	"WEATHER_API_KEY": "api_key",
+}
+
+// EnvVar describes an environment variable Illapaca reads
+type EnvVar struct {
+	Name        string
+	Key         string
+	Description string
+	Secret      bool
+}
+
+// EnvVarName returns the variable that sets key: the prefix plus the key
+// in upper case with dots replaced by underscores, so
+// alert_thresholds.high_temp is read from ILLAPACA_ALERT_THRESHOLDS_HIGH_TEMP
+func EnvVarName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// bindEnv maps every setting to its environment variable. Deprecated names
+// are bound after the primary one, so the primary name wins when both are set.
+func bindEnv() {
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	for _, s := range Settings {
+		names := []string{EnvVarName(s.Key)}
+		for old, key := range deprecatedEnv {
+			if key == s.Key {
+				names = append(names, old)
+			}
+		}
+		viper.BindEnv(append([]string{s.Key}, names...)...)
+	}
+}
+
+// warnDeprecatedEnv tells users still relying on old variable names
+func warnDeprecatedEnv() {
+	for old, key := range deprecatedEnv {
+		if _, ok := os.LookupEnv(old); !ok {
+			continue
+		}
+		if _, ok := os.LookupEnv(EnvVarName(key)); ok {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s is deprecated, use %s instead\n", old, EnvVarName(key))
+	}
+}
+
+// envSource returns the variable currently setting key, if any
+func envSource(key string) (string, bool) {
+	if _, ok := os.LookupEnv(EnvVarName(key)); ok {
+		return EnvVarName(key), true
+	}
+	for old, k := range deprecatedEnv {
+		if k != key {
+			continue
+		}
+		if _, ok := os.LookupEnv(old); ok {
+			return old, true
+		}
+	}
+	return "", false
+}
+
+// EnvironmentVariables lists every variable Illapaca recognizes
+func EnvironmentVariables() []EnvVar {
+	var vars []EnvVar
+	for _, s := range Settings {
+		vars = append(vars, EnvVar{Name: EnvVarName(s.Key), Key: s.Key, Description: s.Description, Secret: s.Secret})
+	}
+
+	vars = append(vars,
+		EnvVar{Name: ProfileEnv, Description: "Profile to use when --profile is not given"},
+		EnvVar{Name: keystore.PassphraseEnv, Description: "Passphrase for the encrypted API key file", Secret: true},
+	)
+
+	for old, key := range deprecatedEnv {
+		vars = append(vars, EnvVar{Name: old, Key: key, Description: "Deprecated, use " + EnvVarName(key), Secret: true})
+	}
+	return vars
+}
+
+// ListEnvironmentVariables prints recognized variables and their values
+func ListEnvironmentVariables() {
+	for _, env := range EnvironmentVariables() {
+		value, ok := os.LookupEnv(env.Name)
+		switch {
+		case !ok:
+			value = "-"
+		case env.Secret:
+			value = redact(value)
+		}
+
+		fmt.Printf("%-42s %-20s %s\n", env.Name, value, env.Description)
+	}
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const testConfigFile = `
+units: metric
+alert_thresholds:
+  high_temp: 30
+profiles:
+  work:
+    units: imperial
+    alert_thresholds:
+      high_temp: 25
+`
+
+// loadConfig runs InitConfig against a temporary config file with a clean
+// environment, then applies env and flag overrides
+func loadConfig(t *testing.T, file string, env, flags map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	for _, v := range EnvironmentVariables() {
+		t.Setenv(v.Name, "")
+		os.Unsetenv(v.Name)
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+
+	path := filepath.Join(dir, "illapaca.yaml")
+	if err := os.WriteFile(path, []byte(file), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	boundFlags = map[string]*pflag.Flag{}
+	CfgFile = path
+	ProfileName = ""
+	t.Cleanup(func() {
+		viper.Reset()
+		CfgFile = ""
+		ProfileName = ""
+	})
+
+	cmd := &cobra.Command{Use: "illapaca"}
+	cmd.PersistentFlags().StringVar(&ProfileName, "profile", "", "")
+	cmd.PersistentFlags().String("api-key", "", "")
+	cmd.PersistentFlags().String("units", "metric", "")
+	BindFlags(cmd)
+	for name, value := range flags {
+		if err := cmd.PersistentFlags().Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	InitConfig()
+}
+
+func TestEnvVarName(t *testing.T) {
+	tests := map[string]string{
+		"api_key":                        "ILLAPACA_API_KEY",
+		"units":                          "ILLAPACA_UNITS",
+		"alert_thresholds.high_temp":     "ILLAPACA_ALERT_THRESHOLDS_HIGH_TEMP",
+		"alert_thresholds.precipitation": "ILLAPACA_ALERT_THRESHOLDS_PRECIPITATION",
+	}
+	for key, want := range tests {
+		if got := EnvVarName(key); got != want {
+			t.Errorf("EnvVarName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		env        map[string]string
+		flags      map[string]string
+		wantUnits  string
+		wantSource string
+		wantHigh   float64
+	}{
+		{
+			name:       "default",
+			file:       "{}\n",
+			wantUnits:  "metric",
+			wantSource: SourceDefault,
+			wantHigh:   35,
+		},
+		{
+			name:       "file over default",
+			file:       testConfigFile,
+			wantUnits:  "metric",
+			wantSource: SourceFile,
+			wantHigh:   30,
+		},
+		{
+			name:       "profile over file",
+			file:       testConfigFile,
+			env:        map[string]string{ProfileEnv: "work"},
+			wantUnits:  "imperial",
+			wantSource: SourceFile,
+			wantHigh:   25,
+		},
+		{
+			name:       "env over profile",
+			file:       testConfigFile,
+			env:        map[string]string{ProfileEnv: "work", "ILLAPACA_UNITS": "metric", "ILLAPACA_ALERT_THRESHOLDS_HIGH_TEMP": "40"},
+			wantUnits:  "metric",
+			wantSource: SourceEnv,
+			wantHigh:   40,
+		},
+		{
+			name:       "flag over env",
+			file:       testConfigFile,
+			env:        map[string]string{"ILLAPACA_UNITS": "metric"},
+			flags:      map[string]string{"units": "imperial"},
+			wantUnits:  "imperial",
+			wantSource: SourceFlag,
+			wantHigh:   30,
+		},
+		{
+			name:       "profile flag over profile env",
+			file:       testConfigFile,
+			env:        map[string]string{ProfileEnv: "default"},
+			flags:      map[string]string{"profile": "work"},
+			wantUnits:  "imperial",
+			wantSource: SourceFile,
+			wantHigh:   25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadConfig(t, tt.file, tt.env, tt.flags)
+
+			if AppConfig.Units != tt.wantUnits {
+				t.Errorf("Units = %q, want %q", AppConfig.Units, tt.wantUnits)
+			}
+			if got := ValueSource("units"); got != tt.wantSource {
+				t.Errorf("ValueSource(units) = %q, want %q", got, tt.wantSource)
+			}
+			if AppConfig.AlertThresholds.HighTemp != tt.wantHigh {
+				t.Errorf("HighTemp = %v, want %v", AppConfig.AlertThresholds.HighTemp, tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestDeprecatedAPIKeyEnv(t *testing.T) {
+	loadConfig(t, "{}\n", map[string]string{"WEATHER_API_KEY": "old"}, nil)
+	if AppConfig.APIKey != "old" {
+		t.Errorf("APIKey = %q, want value from WEATHER_API_KEY", AppConfig.APIKey)
+	}
+
+	loadConfig(t, "{}\n", map[string]string{"WEATHER_API_KEY": "old", "ILLAPACA_API_KEY": "new"}, nil)
+	if AppConfig.APIKey != "new" {
+		t.Errorf("APIKey = %q, want ILLAPACA_API_KEY to win", AppConfig.APIKey)
+	}
+
+	loadConfig(t, "{}\n", map[string]string{"ILLAPACA_API_KEY": "env"}, map[string]string{"api-key": "flag"})
+	if AppConfig.APIKey != "flag" {
+		t.Errorf("APIKey = %q, want --api-key to win", AppConfig.APIKey)
+	}
+}
@@ -3,24 +3,8 @@ package config
 import (
 	"fmt"
 	"strconv"
-
-	"github.com/spf13/viper"
 )
 
-// Save the current config to disk
-func SaveConfig() error {
-	viper.Set("api_key", AppConfig.APIKey)
-	viper.Set("default_location", AppConfig.DefaultLocation)
-	viper.Set("units", AppConfig.Units)
-	viper.Set("favorite_locations", AppConfig.FavoriteLocations)
-	viper.Set("alert_thresholds.high_temp", AppConfig.AlertThresholds.HighTemp)
-	viper.Set("alert_thresholds.low_temp", AppConfig.AlertThresholds.LowTemp)
-	viper.Set("alert_thresholds.precipitation", AppConfig.AlertThresholds.Precipitation)
-	viper.Set("alert_thresholds.wind_speed", AppConfig.AlertThresholds.WindSpeed)
-
-	return viper.WriteConfig()
-}
-
 // List favorite locations
 func ListFavoriteLocations() {
 	if len(AppConfig.FavoriteLocations) == 0 {
@@ -50,8 +34,7 @@ func SaveFavoriteLocation(location string) error {
 	// Add the location to the list
 	AppConfig.FavoriteLocations = append(AppConfig.FavoriteLocations, location)
 
-	// Save the config
-	return SaveConfig()
+	return storeValues(map[string]interface{}{"favorite_locations": AppConfig.FavoriteLocations})
 }
 
 // Remove a favorite location by index
@@ -72,8 +55,7 @@ func RemoveFavoriteLocationByIndex(index int) error {
 		AppConfig.FavoriteLocations[index+1:]...,
 	)
 
-	// Save the config
-	if err := SaveConfig(); err != nil {
+	if err := storeValues(map[string]interface{}{"favorite_locations": AppConfig.FavoriteLocations}); err != nil {
 		return err
 	}
 
@@ -100,8 +82,7 @@ func RemoveFavoriteLocationByName(location string) error {
 
 	AppConfig.FavoriteLocations = newFavoriteLocations
 
-	// Save the config
-	return SaveConfig()
+	return storeValues(map[string]interface{}{"favorite_locations": AppConfig.FavoriteLocations})
 }
 
 // Set the default location
@@ -112,9 +93,8 @@ func SetDefaultLocation(arg string) error {
 		// It's an index
 		defaultLocation := AppConfig.FavoriteLocations[index-1]
 
-		// Save the config
 		AppConfig.DefaultLocation = defaultLocation
-		if err := SaveConfig(); err != nil {
+		if err := storeValues(map[string]interface{}{"default_location": defaultLocation}); err != nil {
 			return err
 		}
 
@@ -125,9 +105,8 @@ func SetDefaultLocation(arg string) error {
 	// It's a location name
 	location := arg
 
-	// Save config
 	AppConfig.DefaultLocation = location
-	if err := SaveConfig(); err != nil {
+	if err := storeValues(map[string]interface{}{"default_location": location}); err != nil {
 		return err
 	}
 
@@ -135,24 +114,29 @@ func SetDefaultLocation(arg string) error {
 	return nil
 }
 
-// Set an alert thresholds
+// Set an alert thresholds. Only the thresholds given (non-zero) are
+// stored.
 func SetAlertThresholds(highTemp, lowTemp, precipitation, windSpeed float64) error {
+	values := map[string]interface{}{}
 	if highTemp != 0 {
 		AppConfig.AlertThresholds.HighTemp = highTemp
+		values["alert_thresholds.high_temp"] = highTemp
 	}
 
 	if lowTemp != 0 {
 		AppConfig.AlertThresholds.LowTemp = lowTemp
+		values["alert_thresholds.low_temp"] = lowTemp
 	}
 
 	if precipitation != 0 {
 		AppConfig.AlertThresholds.Precipitation = precipitation
+		values["alert_thresholds.precipitation"] = precipitation
 	}
 
 	if windSpeed != 0 {
 		AppConfig.AlertThresholds.WindSpeed = windSpeed
+		values["alert_thresholds.wind_speed"] = windSpeed
 	}
 
-	// Save the config
-	return SaveConfig()
+	return storeValues(values)
 }
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/keystore"
+	"github.com/spf13/viper"
+)
+
+// DefaultProfile is the name of the top-level settings in the config file
+const DefaultProfile = "default"
+
+// ProfileEnv selects a profile when --profile is not given
+const ProfileEnv = "ILLAPACA_PROFILE"
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ActiveProfile returns the profile chosen by --profile, ILLAPACA_PROFILE
+// or current_profile in the config file, in that order
+func ActiveProfile() string {
+	if ProfileName != "" {
+		return ProfileName
+	}
+	if env := os.Getenv(ProfileEnv); env != "" {
+		return env
+	}
+	if current := viper.GetString("current_profile"); current != "" {
+		return current
+	}
+	return DefaultProfile
+}
+
+// applyProfile merges a named profile into the file layer of viper, so its
+// values override top-level settings while flags and env still win
+func applyProfile(name string) error {
+	if name == DefaultProfile {
+		return nil
+	}
+
+	sub := viper.Sub("profiles." + name)
+	if sub == nil {
+		return fmt.Errorf("profile %q not found (run 'illapaca config profile create %s')", name, name)
+	}
+	return viper.MergeConfigMap(sub.AllSettings())
+}
+
+// fileKey returns where key is stored in the config file for the active profile
+func fileKey(key string) string {
+	if AppConfig.Profile == "" || AppConfig.Profile == DefaultProfile {
+		return key
+	}
+	return "profiles." + AppConfig.Profile + "." + key
+}
+
+// keystoreAccount returns the key store entry for provider in the active profile
+func keystoreAccount(provider string) string {
+	if AppConfig.Profile == "" || AppConfig.Profile == DefaultProfile {
+		return provider
+	}
+	return AppConfig.Profile + "/" + provider
+}
+
+// profileNames returns the named profiles defined in the config file
+func profileNames(v *viper.Viper) []string {
+	var names []string
+	for name := range v.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ListProfiles prints every profile, marking the active one
+func ListProfiles() error {
+	v, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	active := ActiveProfile()
+	for _, name := range append([]string{DefaultProfile}, profileNames(v)...) {
+		if name == active {
+			fmt.Printf("* %s\n", name)
+		} else {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+	return nil
+}
+
+// CreateProfile adds a profile inheriting every setting, or one copying the active profile's
+// non-secret settings when copyCurrent is set
+func CreateProfile(name string, copyCurrent bool) error {
+	if name == DefaultProfile || !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use lowercase letters, digits, '-' and '_')", name)
+	}
+
+	v, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	if v.IsSet("profiles." + name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+
+	// Viper drops empty maps when writing, so every profile records when
+	// it was created
+	settings := map[string]interface{}{"created": time.Now().Format(time.RFC3339)}
+	if copyCurrent {
+		settings["provider"] = AppConfig.Provider
+		settings["default_location"] = AppConfig.DefaultLocation
+		settings["units"] = AppConfig.Units
+		settings["favorite_locations"] = AppConfig.FavoriteLocations
+		settings["alert_thresholds"] = map[string]interface{}{
+			"high_temp":     AppConfig.AlertThresholds.HighTemp,
+			"low_temp":      AppConfig.AlertThresholds.LowTemp,
+			"precipitation": AppConfig.AlertThresholds.Precipitation,
+			"wind_speed":    AppConfig.AlertThresholds.WindSpeed,
+		}
+	}
+
+	all := v.AllSettings()
+	profiles, _ := all["profiles"].(map[string]interface{})
+	if profiles == nil {
+		profiles = map[string]interface{}{}
+	}
+	profiles[name] = settings
+	all["profiles"] = profiles
+	return writeConfigMap(all)
+}
+
+// UseProfile makes name the profile used when none is selected explicitly
+func UseProfile(name string) error {
+	v, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if name == DefaultProfile {
+		if !v.IsSet("current_profile") {
+			return nil
+		}
+		settings := v.AllSettings()
+		delete(settings, "current_profile")
+		return writeConfigMap(settings)
+	}
+
+	if !v.IsSet("profiles." + name) {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	v.Set("current_profile", name)
+	return v.WriteConfig()
+}
+
+// DeleteProfile removes a profile and any API keys stored for it
+func DeleteProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the default profile cannot be deleted")
+	}
+
+	v, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	settings := v.AllSettings()
+	if !deleteNested(settings, []string{"profiles", name}) {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if current, _ := settings["current_profile"].(string); current == name {
+		delete(settings, "current_profile")
+	}
+
+	if err := writeConfigMap(settings); err != nil {
+		return err
+	}
+
+	for _, provider := range api.Providers {
+		_, store, err := keystore.Lookup(name + "/" + provider)
+		if err != nil {
+			continue
+		}
+		if err := store.Delete(name + "/" + provider); err != nil && !errors.Is(err, keystore.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestCreateEmptyProfile(t *testing.T) {
+	loadConfig(t, "units: imperial\n", nil, nil)
+
+	if err := CreateProfile("work", false); err != nil {
+		t.Fatal(err)
+	}
+	v, err := readConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := profileNames(v); !slices.Equal(names, []string{"work"}) {
+		t.Fatalf("profiles = %v, want [work]", names)
+	}
+	if err := UseProfile("work"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+
+	// The new profile inherits the top-level settings
+	file, err := os.ReadFile(ConfigFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loadConfig(t, string(file), nil, map[string]string{"profile": "work"})
+	if AppConfig.Profile != "work" || AppConfig.Units != "imperial" {
+		t.Errorf("profile %q with units %q, want work with imperial", AppConfig.Profile, AppConfig.Units)
+	}
+}
+
+func TestSaveWritesOnlyChangedKey(t *testing.T) {
+	loadConfig(t, testConfigFile, map[string]string{ProfileEnv: "work", "ILLAPACA_UNITS": "metric"}, nil)
+
+	if err := SaveFavoriteLocation("Cusco"); err != nil {
+		t.Fatal(err)
+	}
+	v, err := readConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.GetStringSlice("profiles.work.favorite_locations"); !slices.Equal(got, []string{"Cusco"}) {
+		t.Errorf("work favorites = %v, want [Cusco]", got)
+	}
+	// Neither the environment's units nor inherited settings are written
+	if got := v.GetString("profiles.work.units"); got != "imperial" {
+		t.Errorf("work units = %q, want imperial as in the file", got)
+	}
+	for _, key := range []string{"profiles.work.default_location", "profiles.work.alert_thresholds.low_temp", "favorite_locations"} {
+		if v.IsSet(key) {
+			t.Errorf("%s was written", key)
+		}
+	}
+}
+
+func TestUnknownProfileFallsBack(t *testing.T) {
+	loadConfig(t, testConfigFile, map[string]string{ProfileEnv: "missing"}, nil)
+
+	if AppConfig.Profile != DefaultProfile || AppConfig.Units != "metric" {
+		t.Errorf("profile %q with units %q, want the default profile", AppConfig.Profile, AppConfig.Units)
+	}
+	if err := CreateProfile("missing", false); err != nil {
+		t.Errorf("CreateProfile after falling back: %v", err)
+	}
+}
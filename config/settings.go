@@ -0,0 +1,291 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Value sources, in order of increasing precedence
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// settingKind describes how a setting value is parsed and stored
+type settingKind int
+
+const (
+	kindString settingKind = iota
+	kindFloat
+	kindList
+	kindBool
+	kindInt
+)
+
+// Setting describes a configuration key that can be viewed and edited
+type Setting struct {
+	Key         string
+	Description string
+	Secret      bool
+	kind        settingKind
+}
+
+// Settings lists every key in Config, in display order
+var Settings = []Setting{
+	{Key: "provider", Description: "Weather provider (weatherapi or openweathermap)", kind: kindString},
+	{Key: "api_key", Description: "API key for weather service", Secret: true, kind: kindString},
+	{Key: "provider_url", Description: "Override the provider server URL, e.g. for a fake server", kind: kindString},
+	{Key: "default_location", Description: "Location used when none is given", kind: kindString},
+	{Key: "units", Description: "Units to display (metric or imperial)", kind: kindString},
+	{Key: "favorite_locations", Description: "Favorite locations (comma separated)", kind: kindList},
+	{Key: "alert_thresholds.high_temp", Description: "High temperature threshold (°C)", kind: kindFloat},
+	{Key: "alert_thresholds.low_temp", Description: "Low temperature threshold (°C)", kind: kindFloat},
+	{Key: "alert_thresholds.precipitation", Description: "Precipitation chance threshold (%)", kind: kindFloat},
+	{Key: "alert_thresholds.wind_speed", Description: "Wind speed threshold (km/h)", kind: kindFloat},
+	{Key: "history.enabled", Description: "Record fetched weather in the local history database", kind: kindBool},
+	{Key: "history.path", Description: "History database file (default ~/.illapaca-history.db)", kind: kindString},
+	{Key: "history.min_readings", Description: "Fewest recorded readings a day needs to count in climate statistics and forecast accuracy", kind: kindInt},
+	{Key: "serve.token", Description: "Bearer token required by 'serve --http'", Secret: true, kind: kindString},
+	{Key: "theme", Description: "Color theme: default, high-contrast, solarized, colorblind-safe or one under themes", kind: kindString},
+	{Key: "icons", Description: "Icon set: emoji, nerd (Nerd Font), ascii or art (ASCII art for current conditions)", kind: kindString},
+	{Key: "no_color", Description: "Disable colored output (also NO_COLOR)", kind: kindBool},
+	{Key: "accessible", Description: "Screen reader friendly output: text instead of charts, icons and tables", kind: kindBool},
+	{Key: "lang", Description: "Output language: en, es, fr, de or qu (default from LANG)", kind: kindString},
+	{Key: "templates.current", Description: "Template file replacing the 'current' report", kind: kindString},
+	{Key: "templates.forecast", Description: "Template file replacing the 'forecast' report", kind: kindString},
+}
+
+// boundFlags remembers which command flags override which keys
+var boundFlags = map[string]*pflag.Flag{}
+
+// LookupSetting returns the setting registered for key
+func LookupSetting(key string) (Setting, error) {
+	key = strings.ToLower(key)
+	for _, s := range Settings {
+		if s.Key == key {
+			return s, nil
+		}
+	}
+	return Setting{}, fmt.Errorf("unknown config key %q (run 'illapaca config list' to see all keys)", key)
+}
+
+// ConfigFilePath returns the path of the config file in use
+func ConfigFilePath() string {
+	if CfgFile != "" {
+		return CfgFile
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".illapaca.yaml"
+	}
+	return filepath.Join(home, ".illapaca.yaml")
+}
+
+// ValueSource reports where the effective value of key comes from
+func ValueSource(key string) string {
+	if flag, ok := boundFlags[key]; ok && flag != nil && flag.Changed {
+		return SourceFlag
+	}
+	if _, ok := envSource(key); ok {
+		return SourceEnv
+	}
+	if viper.InConfig(key) {
+		return SourceFile
+	}
+	return SourceDefault
+}
+
+// DisplayValue returns the effective value of key formatted for output.
+// Secret values are redacted unless reveal is set.
+func DisplayValue(s Setting, reveal bool) string {
+	var value string
+	switch s.kind {
+	case kindList:
+		value = strings.Join(viper.GetStringSlice(s.Key), ", ")
+	case kindFloat:
+		value = strconv.FormatFloat(viper.GetFloat64(s.Key), 'f', -1, 64)
+	case kindBool:
+		value = strconv.FormatBool(viper.GetBool(s.Key))
+	case kindInt:
+		value = strconv.Itoa(viper.GetInt(s.Key))
+	default:
+		value = viper.GetString(s.Key)
+	}
+
+	if s.Secret && !reveal {
+		return redact(value)
+	}
+	return value
+}
+
+// redact hides all but the last four characters of a secret
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return strings.Repeat("*", len(value)-4) + value[len(value)-4:]
+}
+
+// ListSettings prints every setting with its effective value and source
+func ListSettings(reveal bool) {
+	fmt.Printf("Config file: %s\n", ConfigFilePath())
+	fmt.Printf("Profile:     %s\n\n", AppConfig.Profile)
+	for _, s := range Settings {
+		fmt.Printf("%-32s %-24s (%s)\n", s.Key, DisplayValue(s, reveal), ValueSource(s.Key))
+	}
+}
+
+// readConfigFile loads only the config file, without defaults, env or flags
+func readConfigFile() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(ConfigFilePath())
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return v, nil
+}
+
+// parseValue converts raw command line input to the type stored for s
+func parseValue(s Setting, raw string) (interface{}, error) {
+	switch s.kind {
+	case kindFloat:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a number, got %q", s.Key, raw)
+		}
+		return f, nil
+	case kindInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s expects a whole number, got %q", s.Key, raw)
+		}
+		return n, nil
+	case kindBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects true or false, got %q", s.Key, raw)
+		}
+		return b, nil
+	case kindList:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	default:
+		if s.Key == "units" && raw != "metric" && raw != "imperial" {
+			return nil, fmt.Errorf("units must be metric or imperial, got %q", raw)
+		}
+		if s.Key == "provider" {
+			if err := api.ValidateProvider(raw); err != nil {
+				return nil, err
+			}
+		}
+		return raw, nil
+	}
+}
+
+// SetValue stores a value for key in the config file
+func SetValue(key, raw string) error {
+	s, err := LookupSetting(key)
+	if err != nil {
+		return err
+	}
+
+	value, err := parseValue(s, raw)
+	if err != nil {
+		return err
+	}
+
+	return storeValues(map[string]interface{}{s.Key: value})
+}
+
+// storeValues writes values by key into the active profile in the config
+// file, leaving every other key as it is in the file
+func storeValues(values map[string]interface{}) error {
+	v, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		v.Set(fileKey(key), value)
+	}
+	return v.WriteConfig()
+}
+
+// UnsetValue removes key from the config file so its default applies again
+func UnsetValue(key string) error {
+	s, err := LookupSetting(key)
+	if err != nil {
+		return err
+	}
+
+	v, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	settings := v.AllSettings()
+	if !deleteNested(settings, strings.Split(fileKey(s.Key), ".")) {
+		return fmt.Errorf("%s is not set in %s", s.Key, ConfigFilePath())
+	}
+	return writeConfigMap(settings)
+}
+
+// writeConfigMap replaces the contents of the config file with settings
+func writeConfigMap(settings map[string]interface{}) error {
+	out := viper.New()
+	out.SetConfigFile(ConfigFilePath())
+	out.SetConfigType("yaml")
+	if err := out.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	return out.WriteConfig()
+}
+
+// deleteNested removes the value at path from a nested settings map
+func deleteNested(m map[string]interface{}, path []string) bool {
+	if len(path) == 1 {
+		if _, ok := m[path[0]]; !ok {
+			return false
+		}
+		delete(m, path[0])
+		return true
+	}
+
+	child, ok := m[path[0]].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	return deleteNested(child, path[1:])
+}
+
+// SettingKeys returns all known keys, sorted, for shell completion
+func SettingKeys() []string {
+	keys := make([]string, 0, len(Settings))
+	for _, s := range Settings {
+		keys = append(keys, s.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
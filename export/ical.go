@@ -0,0 +1,158 @@
+// Package export writes forecasts in formats other programs can consume
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/biferdou/illapaca/model"
+	"github.com/biferdou/illapaca/ui"
+)
+
+// ICalOptions controls the calendar produced by ICal
+type ICalOptions struct {
+	// Alerts adds an event for each day that crosses a threshold
+	Alerts bool
+
+	// AlertThresholds decide which days get alert events
+	AlertThresholds model.AlertThresholds
+
+	// Now stamps the events; the zero value uses the current time
+	Now time.Time
+}
+
+// icalDate is the format of an all-day DATE value
+const icalDate = "20060102"
+
+// ICal writes an iCalendar (RFC 5545) feed with one all-day event per
+// forecast day, and optionally one per threshold alert
+func ICal(w io.Writer, data *model.WeatherData, opts ICalOptions) error {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	place := strings.TrimSuffix(data.Location.Name+", "+data.Location.Country, ", ")
+	uidLocation := strings.ToLower(strings.ReplaceAll(data.Location.Name, " ", "-"))
+
+	c := &calendarWriter{w: w}
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:-//Illapaca//Weather Forecast//EN")
+	c.line("CALSCALE:GREGORIAN")
+	c.line("METHOD:PUBLISH")
+	c.property("X-WR-CALNAME", "Weather: "+place)
+	c.line("REFRESH-INTERVAL;VALUE=DURATION:PT6H")
+	c.line("X-PUBLISHED-TTL:PT6H")
+
+	for _, day := range data.Forecast.ForecastDay {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+
+		summary := fmt.Sprintf("%s %s %.0f°C/%.0f°C, %d%% rain",
+			ui.ConditionIcon(day.Day.Condition, true), day.Day.Condition.Text,
+			day.Day.MaxTempC, day.Day.MinTempC, day.Day.DailyChanceOfRain)
+		description := fmt.Sprintf("%s\nHigh %.1f°C, low %.1f°C\nChance of rain %d%%\nSunrise %s\nSunset %s",
+			day.Day.Condition.Text, day.Day.MaxTempC, day.Day.MinTempC, day.Day.DailyChanceOfRain,
+			ui.OrPlaceholder(day.Astro.Sunrise), ui.OrPlaceholder(day.Astro.Sunset))
+
+		c.event(fmt.Sprintf("%s-%s@illapaca", date.Format(icalDate), uidLocation), stamp, date, summary, description, place)
+
+		if !opts.Alerts {
+			continue
+		}
+		for i, alert := range dayAlerts(day, opts.AlertThresholds) {
+			c.event(fmt.Sprintf("%s-%s-alert-%d@illapaca", date.Format(icalDate), uidLocation, i+1),
+				stamp, date, "⚠️ "+alert, alert, place)
+		}
+	}
+
+	c.line("END:VCALENDAR")
+	return c.err
+}
+
+// dayAlerts lists the thresholds a forecast day crosses
+func dayAlerts(day model.ForecastDay, thresholds model.AlertThresholds) []string {
+	var alerts []string
+	if day.Day.MaxTempC > thresholds.HighTemp {
+		alerts = append(alerts, fmt.Sprintf("High temperature %.1f°C (threshold %.1f°C)", day.Day.MaxTempC, thresholds.HighTemp))
+	}
+	if day.Day.MinTempC < thresholds.LowTemp {
+		alerts = append(alerts, fmt.Sprintf("Low temperature %.1f°C (threshold %.1f°C)", day.Day.MinTempC, thresholds.LowTemp))
+	}
+	if day.Day.DailyChanceOfRain > int(thresholds.Precipitation) {
+		alerts = append(alerts, fmt.Sprintf("Chance of rain %d%% (threshold %.0f%%)", day.Day.DailyChanceOfRain, thresholds.Precipitation))
+	}
+	if day.Day.MaxWindKph > thresholds.WindSpeed {
+		alerts = append(alerts, fmt.Sprintf("Wind up to %.1f km/h (threshold %.1f km/h)", day.Day.MaxWindKph, thresholds.WindSpeed))
+	}
+	return alerts
+}
+
+// calendarWriter writes content lines, remembering the first error
+type calendarWriter struct {
+	w   io.Writer
+	err error
+}
+
+// event writes an all-day VEVENT on date
+func (c *calendarWriter) event(uid, stamp string, date time.Time, summary, description, location string) {
+	c.line("BEGIN:VEVENT")
+	c.property("UID", uid)
+	c.line("DTSTAMP:" + stamp)
+	c.line("DTSTART;VALUE=DATE:" + date.Format(icalDate))
+	c.line("DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format(icalDate))
+	c.property("SUMMARY", summary)
+	c.property("DESCRIPTION", description)
+	c.property("LOCATION", location)
+	c.line("TRANSP:TRANSPARENT")
+	c.line("END:VEVENT")
+}
+
+// property writes a property with an escaped text value
+func (c *calendarWriter) property(name, value string) {
+	c.line(name + ":" + escapeText(value))
+}
+
+// line writes one content line, folded to 75 octets and ended with CRLF
+func (c *calendarWriter) line(s string) {
+	if c.err != nil {
+		return
+	}
+	_, c.err = io.WriteString(c.w, fold(s)+"\r\n")
+}
+
+// escapeText escapes a TEXT value
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold splits a content line into lines of at most 75 octets, continued
+// with a leading space, without breaking UTF-8 sequences
+func fold(s string) string {
+	const limit = 75
+	var b strings.Builder
+	width := 0
+	for len(s) > 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteString(s[:size])
+		width += size
+		s = s[size:]
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+func testWeather() *model.WeatherData {
+	return &model.WeatherData{
+		Location: model.Location{Name: "Cusco", Country: "Peru"},
+		Forecast: model.Forecast{ForecastDay: []model.ForecastDay{
+			{
+				Date:  "2025-10-17",
+				Day:   model.Day{MaxTempC: 19.4, MinTempC: 4.2, DailyChanceOfRain: 20, Condition: model.Condition{Text: "Sunny"}},
+				Astro: model.Astro{Sunrise: "05:21 AM", Sunset: "05:51 PM"},
+			},
+			{
+				Date: "2025-10-18",
+				Day:  model.Day{MaxTempC: 16, MinTempC: -1, DailyChanceOfRain: 90, Condition: model.Condition{Text: "Heavy rain, thunder"}},
+			},
+		}},
+	}
+}
+
+func TestICal(t *testing.T) {
+	var buf bytes.Buffer
+	err := ICal(&buf, testWeather(), ICalOptions{
+		Alerts:          true,
+		AlertThresholds: model.AlertThresholds{HighTemp: 30, LowTemp: 0, Precipitation: 70, WindSpeed: 30},
+		Now:             time.Date(2025, 10, 17, 8, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Weather: Cusco\\, Peru\r\n",
+		"DTSTAMP:20251017T080000Z\r\n",
+		"DTSTART;VALUE=DATE:20251017\r\nDTEND;VALUE=DATE:20251018\r\n",
+		"UID:20251018-cusco@illapaca\r\n",
+		"Heavy rain\\, thunder 16°C/-1°C\\, 90% rain",
+		"Sunrise 05:21 AM\\nSunset 05:51 PM",
+		"Sunset --",
+		"UID:20251018-cusco-alert-2@illapaca\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("calendar missing %q:\n%s", want, unfolded)
+		}
+	}
+
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 4 {
+		t.Errorf("got %d events, want 2 days and 2 alerts", n)
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+}
+
+func TestFoldKeepsRunes(t *testing.T) {
+	long := "SUMMARY:" + strings.Repeat("🌧️", 20)
+	folded := fold(long)
+
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != long {
+		t.Errorf("unfolding changed the line: %q", got)
+	}
+	for _, line := range strings.Split(folded, "\r\n") {
+		if !strings.HasPrefix(line, " ") && line != strings.Split(folded, "\r\n")[0] {
+			t.Errorf("continuation line without leading space: %q", line)
+		}
+	}
+}
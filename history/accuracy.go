@@ -0,0 +1,122 @@
+package history
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// accuracyKey groups samples by provider and lead time
+type accuracyKey struct {
+	provider string
+	lead     int
+}
+
+// errorSums accumulates absolute and signed errors
+type errorSums struct {
+	abs, signed float64
+}
+
+func (e *errorSums) add(forecast, observed float64) {
+	e.abs += math.Abs(forecast - observed)
+	e.signed += forecast - observed
+}
+
+func (e errorSums) stats(n int) model.ErrorStats {
+	return model.ErrorStats{MAE: e.abs / float64(n), Bias: e.signed / float64(n)}
+}
+
+// issueDate returns the local date a forecast was issued, falling back to
+// the UTC date for records without one
+func issueDate(f model.ForecastRecord) string {
+	if f.IssuedDate != "" {
+		return f.IssuedDate
+	}
+	return f.IssuedAt.UTC().Format("2006-01-02")
+}
+
+// leadDays returns how many days ahead of its issue date a forecast day is
+func leadDays(f model.ForecastRecord) (int, bool) {
+	from, err := time.Parse("2006-01-02", issueDate(f))
+	if err != nil {
+		return 0, false
+	}
+	to, err := time.Parse("2006-01-02", f.Day.Date)
+	if err != nil {
+		return 0, false
+	}
+	return int(to.Sub(from).Hours() / 24), true
+}
+
+// Accuracy compares forecasts with the days later observed and scores
+// them per provider and lead time. When a provider issued several
+// forecasts for the same day on the same date, only the latest counts.
+func Accuracy(forecasts []model.ForecastRecord, observed []model.ObservedDay) []model.ForecastAccuracy {
+	actual := make(map[string]model.ObservedDay, len(observed))
+	for _, day := range observed {
+		actual[day.Date] = day
+	}
+
+	// Keep the latest forecast per provider, issue date and target date;
+	// forecasts are stored oldest first
+	type issueKey struct {
+		provider, issued, target string
+	}
+	latest := map[issueKey]model.ForecastRecord{}
+	for _, f := range forecasts {
+		latest[issueKey{f.Provider, issueDate(f), f.Day.Date}] = f
+	}
+
+	type sums struct {
+		n                int
+		maxTemp, minTemp errorSums
+		rain             errorSums
+	}
+	groups := map[accuracyKey]*sums{}
+	for _, f := range latest {
+		obs, ok := actual[f.Day.Date]
+		if !ok {
+			continue
+		}
+		lead, ok := leadDays(f)
+		if !ok || lead < 0 {
+			continue
+		}
+
+		key := accuracyKey{f.Provider, lead}
+		g := groups[key]
+		if g == nil {
+			g = &sums{}
+			groups[key] = g
+		}
+		rained := 0.0
+		if obs.Rained {
+			rained = 100
+		}
+		g.n++
+		g.maxTemp.add(f.Day.Day.MaxTempC, obs.MaxTempC)
+		g.minTemp.add(f.Day.Day.MinTempC, obs.MinTempC)
+		g.rain.add(float64(f.Day.Day.DailyChanceOfRain), rained)
+	}
+
+	results := make([]model.ForecastAccuracy, 0, len(groups))
+	for key, g := range groups {
+		results = append(results, model.ForecastAccuracy{
+			Provider: key.provider,
+			LeadDays: key.lead,
+			Samples:  g.n,
+			MaxTemp:  g.maxTemp.stats(g.n),
+			MinTemp:  g.minTemp.stats(g.n),
+			Rain:     g.rain.stats(g.n),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Provider != results[j].Provider {
+			return results[i].Provider < results[j].Provider
+		}
+		return results[i].LeadDays < results[j].LeadDays
+	})
+	return results
+}
@@ -0,0 +1,72 @@
+package history
+
+import (
+	"math"
+	"testing"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+func forecast(provider, issued, date string, maxC, minC float64, rain int) model.ForecastRecord {
+	return model.ForecastRecord{
+		Provider:   provider,
+		IssuedDate: issued,
+		Day: model.ForecastDay{
+			Date: date,
+			Day:  model.Day{MaxTempC: maxC, MinTempC: minC, DailyChanceOfRain: rain},
+		},
+	}
+}
+
+func TestAccuracy(t *testing.T) {
+	observed := []model.ObservedDay{
+		{Date: "2025-10-18", MaxTempC: 16, MinTempC: 6, Rained: true},
+		{Date: "2025-10-19", MaxTempC: 14, MinTempC: 4},
+	}
+	forecasts := []model.ForecastRecord{
+		// superseded by the later forecast issued the same day
+		forecast("weatherapi", "2025-10-17", "2025-10-18", 30, 20, 0),
+		forecast("weatherapi", "2025-10-17", "2025-10-18", 18, 5, 80),
+		forecast("weatherapi", "2025-10-18", "2025-10-19", 13, 4, 20),
+		forecast("weatherapi", "2025-10-17", "2025-10-19", 11, 2, 40),
+		forecast("openweathermap", "2025-10-17", "2025-10-18", 15, 7, 60),
+		// never observed
+		forecast("weatherapi", "2025-10-19", "2025-10-20", 10, 0, 0),
+	}
+
+	results := Accuracy(forecasts, observed)
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(results), results)
+	}
+
+	owm := results[0]
+	if owm.Provider != "openweathermap" || owm.LeadDays != 1 || owm.Samples != 1 {
+		t.Errorf("unexpected first result %+v", owm)
+	}
+
+	oneDay := results[1]
+	if oneDay.Provider != "weatherapi" || oneDay.LeadDays != 1 || oneDay.Samples != 2 {
+		t.Fatalf("unexpected second result %+v", oneDay)
+	}
+	// max errors +2 and -1, min errors -1 and 0, rain errors -20 and +20
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"max MAE", oneDay.MaxTemp.MAE, 1.5},
+		{"max bias", oneDay.MaxTemp.Bias, 0.5},
+		{"min MAE", oneDay.MinTemp.MAE, 0.5},
+		{"min bias", oneDay.MinTemp.Bias, -0.5},
+		{"rain MAE", oneDay.Rain.MAE, 20},
+		{"rain bias", oneDay.Rain.Bias, 0},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	if twoDay := results[2]; twoDay.LeadDays != 2 || twoDay.MaxTemp.Bias != -3 {
+		t.Errorf("unexpected two day result %+v", twoDay)
+	}
+}
@@ -0,0 +1,258 @@
+// Package history records fetched weather in a local BoltDB database so
+// past conditions and forecasts can be queried without a provider's paid
+// history API.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bucket names. Each holds one nested bucket per location.
+var (
+	observationsBucket = []byte("observations")
+	forecastsBucket    = []byte("forecasts")
+	daysBucket         = []byte("days")
+)
+
+// openTimeout bounds how long Open waits for another process (such as a
+// running 'illapaca log') to release the database
+const openTimeout = 2 * time.Second
+
+// ErrBusy is returned when another process holds the database
+var ErrBusy = errors.New("history database is in use by another process")
+
+// Store is an open history database
+type Store struct {
+	db  *bolt.DB
+	now func() time.Time
+}
+
+// DefaultPath returns ~/.illapaca-history.db
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".illapaca-history.db"
+	}
+	return filepath.Join(home, ".illapaca-history.db")
+}
+
+// Open opens or creates the database at path
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, ErrBusy
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening history database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{observationsBucket, forecastsBucket, daysBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db, now: time.Now}, nil
+}
+
+// Close releases the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// normalize turns a location query into its bucket name, so "Cusco" and
+// " cusco" share a history
+func normalize(location string) []byte {
+	return []byte(strings.ToLower(strings.TrimSpace(location)))
+}
+
+// timeKey encodes t so that keys sort chronologically. Keys continue with
+// the provider (and forecast date) so simultaneous records do not collide.
+func timeKey(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Record stores the current conditions and every forecast day in data,
+// stamped with the current time
+func (s *Store) Record(provider, location string, data *model.WeatherData) error {
+	now := s.now().UTC().Truncate(time.Second)
+	observation := model.Observation{
+		Location:   location,
+		Provider:   provider,
+		ObservedAt: now,
+		Place:      data.Location,
+		Current:    data.Current,
+	}
+	issuedDate := LocalDate(observation)
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		obs, err := tx.Bucket(observationsBucket).CreateBucketIfNotExists(normalize(location))
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(observation)
+		if err != nil {
+			return err
+		}
+		if err := obs.Put([]byte(timeKey(now)+"|"+provider), value); err != nil {
+			return err
+		}
+
+		forecasts, err := tx.Bucket(forecastsBucket).CreateBucketIfNotExists(normalize(location))
+		if err != nil {
+			return err
+		}
+		for _, day := range data.Forecast.ForecastDay {
+			value, err := json.Marshal(model.ForecastRecord{
+				Location:   location,
+				Provider:   provider,
+				IssuedAt:   now,
+				IssuedDate: issuedDate,
+				Day:        day,
+			})
+			if err != nil {
+				return err
+			}
+			if err := forecasts.Put([]byte(timeKey(now)+"|"+provider+"|"+day.Date), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// scan decodes every value in a location's bucket whose key falls in
+// [from, to). A zero to means no upper bound.
+func (s *Store) scan(bucket []byte, location string, from, to time.Time, decode func([]byte) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket).Bucket(normalize(location))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		start := []byte(timeKey(from))
+		end := timeKey(to)
+		for k, v := c.Seek(start); k != nil; k, v = c.Next() {
+			if !to.IsZero() && string(k) >= end {
+				break
+			}
+			if err := decode(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Observations returns the observations recorded for location between
+// from and to, oldest first. A zero to means no upper bound.
+func (s *Store) Observations(location string, from, to time.Time) ([]model.Observation, error) {
+	var observations []model.Observation
+	err := s.scan(observationsBucket, location, from, to, func(v []byte) error {
+		var o model.Observation
+		if err := json.Unmarshal(v, &o); err != nil {
+			return err
+		}
+		observations = append(observations, o)
+		return nil
+	})
+	return observations, err
+}
+
+// Forecasts returns the forecast days issued for location between from
+// and to, oldest issue first. A zero to means no upper bound.
+func (s *Store) Forecasts(location string, from, to time.Time) ([]model.ForecastRecord, error) {
+	var forecasts []model.ForecastRecord
+	err := s.scan(forecastsBucket, location, from, to, func(v []byte) error {
+		var f model.ForecastRecord
+		if err := json.Unmarshal(v, &f); err != nil {
+			return err
+		}
+		forecasts = append(forecasts, f)
+		return nil
+	})
+	return forecasts, err
+}
+
+// RecordDay stores a day of observed weather from a provider's history
+func (s *Store) RecordDay(provider, location string, day model.ObservedDay) error {
+	value, err := json.Marshal(day)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(daysBucket).CreateBucketIfNotExists(normalize(location))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(day.Date+"|"+provider), value)
+	})
+}
+
+// Days returns the provider history days stored for location, oldest
+// first. A date fetched from several providers appears once per provider.
+func (s *Store) Days(location string) ([]model.ObservedDay, error) {
+	var days []model.ObservedDay
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(daysBucket).Bucket(normalize(location))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var day model.ObservedDay
+			if err := json.Unmarshal(v, &day); err != nil {
+				return err
+			}
+			days = append(days, day)
+			return nil
+		})
+	})
+	return days, err
+}
+
+// Locations returns every location with recorded observations, sorted
+func (s *Store) Locations() ([]string, error) {
+	var locations []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(observationsBucket).ForEachBucket(func(name []byte) error {
+			locations = append(locations, string(name))
+			return nil
+		})
+	})
+	sort.Strings(locations)
+	return locations, err
+}
+
+// Recorder records into the database at Path, opening it only for the
+// duration of each write so that several processes can share it
+type Recorder struct {
+	Path string
+}
+
+// Record opens the database, stores data and closes it again
+func (r Recorder) Record(provider, location string, data *model.WeatherData) error {
+	store, err := Open(r.Path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return store.Record(provider, location, data)
+}
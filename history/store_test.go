@@ -0,0 +1,120 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func weather(localtime string, temp float64, dates ...string) *model.WeatherData {
+	data := &model.WeatherData{
+		Location: model.Location{Name: "Cusco", Localtime: localtime},
+		Current:  model.CurrentWeather{TempC: temp, WindKph: temp / 2},
+	}
+	for _, date := range dates {
+		data.Forecast.ForecastDay = append(data.Forecast.ForecastDay, model.ForecastDay{Date: date})
+	}
+	return data
+}
+
+func TestRecordAndQuery(t *testing.T) {
+	store := openTestStore(t)
+
+	start := time.Date(2025, 10, 17, 9, 0, 0, 0, time.UTC)
+	readings := []struct {
+		at        time.Time
+		localtime string
+		temp      float64
+	}{
+		{start, "2025-10-17 04:00", 4},
+		{start.Add(6 * time.Hour), "2025-10-17 10:00", 16},
+		{start.Add(24 * time.Hour), "2025-10-18 04:00", 6},
+	}
+	for _, r := range readings {
+		store.now = func() time.Time { return r.at }
+		data := weather(r.localtime, r.temp, r.localtime[:10], "2025-10-19")
+		if err := store.Record("weatherapi", "Cusco", data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	observations, err := store.Observations(" cusco", start, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(observations) != 3 {
+		t.Fatalf("got %d observations, want 3", len(observations))
+	}
+
+	observations, err = store.Observations("Cusco", start.Add(time.Hour), start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(observations) != 1 || observations[0].Current.TempC != 16 {
+		t.Errorf("range query returned %+v", observations)
+	}
+
+	forecasts, err := store.Forecasts("Cusco", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forecasts) != 6 {
+		t.Errorf("got %d forecast records, want 6", len(forecasts))
+	}
+
+	locations, err := store.Locations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 1 || locations[0] != "cusco" {
+		t.Errorf("locations = %v, want [cusco]", locations)
+	}
+}
+
+func TestDailySummaries(t *testing.T) {
+	observations := []model.Observation{
+		{Place: model.Location{Localtime: "2025-10-17 04:00"}, Current: model.CurrentWeather{TempC: 4}},
+		{Place: model.Location{Localtime: "2025-10-17 10:00"}, Current: model.CurrentWeather{TempC: 16, PrecipMm: 0.4}},
+		{Place: model.Location{Localtime: "2025-10-18 04:00"}, Current: model.CurrentWeather{TempC: 6}},
+	}
+
+	days := DailySummaries(observations)
+	if len(days) != 2 {
+		t.Fatalf("got %d days, want 2", len(days))
+	}
+	want := model.ObservedDay{Date: "2025-10-17", MinTempC: 4, MaxTempC: 16, AvgTempC: 10, Rained: true, Readings: 2}
+	if days[0] != want {
+		t.Errorf("day 1 = %+v, want %+v", days[0], want)
+	}
+	if days[1].Readings != 1 || days[1].Rained {
+		t.Errorf("day 2 = %+v", days[1])
+	}
+}
+
+func TestMinReadings(t *testing.T) {
+	days := []model.ObservedDay{
+		{Date: "2025-10-16", MaxTempC: 14, Readings: 6},
+		{Date: "2025-10-17", MaxTempC: 4, Readings: 1},
+		{Date: "2025-10-18", MaxTempC: 15, Readings: 24},
+	}
+
+	kept := MinReadings(days, 4)
+	if len(kept) != 2 || kept[0].Date != "2025-10-16" || kept[1].Date != "2025-10-18" {
+		t.Errorf("kept %+v, want the days with 6 and 24 readings", kept)
+	}
+	if got := MinReadings(days, 0); len(got) != 3 {
+		t.Errorf("min 0 kept %d days, want all 3", len(got))
+	}
+}
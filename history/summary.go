@@ -0,0 +1,112 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// LocalDate returns the date of an observation in the observed location's
+// own time zone, falling back to UTC when it is unknown
+func LocalDate(o model.Observation) string {
+	if t, err := time.Parse("2006-01-02 15:04", o.Place.Localtime); err == nil {
+		return t.Format("2006-01-02")
+	}
+	if loc, err := time.LoadLocation(o.Place.TzID); err == nil && o.Place.TzID != "" {
+		return o.ObservedAt.In(loc).Format("2006-01-02")
+	}
+	return o.ObservedAt.UTC().Format("2006-01-02")
+}
+
+// DailySummaries groups observations by local date, oldest first
+func DailySummaries(observations []model.Observation) []model.ObservedDay {
+	var days []model.ObservedDay
+	var total float64
+
+	for _, o := range observations {
+		date := LocalDate(o)
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			if len(days) > 0 {
+				days[len(days)-1].AvgTempC = total / float64(days[len(days)-1].Readings)
+			}
+			days = append(days, model.ObservedDay{
+				Date:     date,
+				MinTempC: o.Current.TempC,
+				MaxTempC: o.Current.TempC,
+			})
+			total = 0
+		}
+
+		day := &days[len(days)-1]
+		day.MinTempC = min(day.MinTempC, o.Current.TempC)
+		day.MaxTempC = max(day.MaxTempC, o.Current.TempC)
+		day.MaxWindKph = max(day.MaxWindKph, o.Current.WindKph)
+		day.Rained = day.Rained || o.Current.PrecipMm > 0
+		day.Readings++
+		total += o.Current.TempC
+	}
+
+	if len(days) > 0 {
+		days[len(days)-1].AvgTempC = total / float64(days[len(days)-1].Readings)
+	}
+	return days
+}
+
+// HistoryDays converts a provider's history response into observed days
+func HistoryDays(data *model.HistoricalData) []model.ObservedDay {
+	days := make([]model.ObservedDay, 0, len(data.Forecast.ForecastDay))
+	for _, day := range data.Forecast.ForecastDay {
+		days = append(days, model.ObservedDay{
+			Date:       day.Date,
+			MinTempC:   day.Day.MinTempC,
+			MaxTempC:   day.Day.MaxTempC,
+			AvgTempC:   day.Day.AvgTempC,
+			MaxWindKph: day.Day.MaxWindKph,
+			Rained:     day.Day.TotalPrecipMm > 0,
+			Readings:   len(day.Hour),
+		})
+	}
+	return days
+}
+
+// MinReadings returns the days with at least min readings. Days with few
+// readings give a poor picture of the real extremes.
+func MinReadings(days []model.ObservedDay, min int) []model.ObservedDay {
+	var kept []model.ObservedDay
+	for _, day := range days {
+		if day.Readings >= min {
+			kept = append(kept, day)
+		}
+	}
+	return kept
+}
+
+// AllDays returns every observed day for location, oldest first. Days from
+// a provider's history are preferred over summaries of recorded
+// observations for the same date.
+func (s *Store) AllDays(location string) ([]model.ObservedDay, error) {
+	observations, err := s.Observations(location, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.Days(location)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := map[string]model.ObservedDay{}
+	for _, day := range DailySummaries(observations) {
+		byDate[day.Date] = day
+	}
+	for _, day := range stored {
+		byDate[day.Date] = day
+	}
+
+	days := make([]model.ObservedDay, 0, len(byDate))
+	for _, day := range byDate {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days, nil
+}
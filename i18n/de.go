@@ -0,0 +1,309 @@
+package i18n
+
+var german = &Locale{
+	Tag:            "de",
+	Name:           "Deutsch",
+	ProviderLang:   "de",
+	Decimal:        ",",
+	DateLayout:     "02.01.2006",
+	DayMonthLayout: "2. Jan",
+	ClockLayout:    "15:04",
+	Months:         [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sep.", "Okt.", "Nov.", "Dez."},
+	messages: map[string]string{
+		// Current conditions
+		"Current Weather":            "Aktuelles Wetter",
+		"Local time: %s":             "Ortszeit: %s",
+		"Condition: %s":              "Wetterlage: %s",
+		"Temperature: ":              "Temperatur: ",
+		"Feels like: ":               "Gefühlt: ",
+		"Wind:":                      "Wind:",
+		"Humidity:":                  "Luftfeuchte:",
+		"Precip:":                    "Niederschl.:",
+		"Visibility:":                "Sichtweite:",
+		"UV Index:":                  "UV-Index:",
+		"N":                          "N",
+		"E":                          "O",
+		"S":                          "S",
+		"W":                          "W",
+		"unusually warm":             "ungewöhnlich warm",
+		"unusually cold":             "ungewöhnlich kalt",
+		"Normal for %s":              "Normal für den %s",
+		"record":                     "Rekord",
+		"record high":                "Wärmerekord",
+		"record low":                 "Kälterekord",
+		"vs Normal":                  "ggü. Normal",
+		"Weather alerts:":            "Wetterwarnungen:",
+		"WEATHER ALERTS":             "WETTERWARNUNGEN",
+		"%.1f°C above normal for %s": "%.1f°C über dem Normalwert für den %s",
+		"%.1f°C below normal for %s": "%.1f°C unter dem Normalwert für den %s",
+		"Record high for %s (previous %.1f°C in %s)": "Wärmerekord für den %s (bisher %.1f°C im Jahr %s)",
+		"Record low for %s (previous %.1f°C in %s)":  "Kälterekord für den %s (bisher %.1f°C im Jahr %s)",
+
+		// Conditions, for providers without the language
+		"Unknown conditions": "Unbekannte Wetterlage",
+		"Clear":              "Klar",
+		"Partly cloudy":      "Teilweise bewölkt",
+		"Cloudy":             "Bewölkt",
+		"Fog":                "Nebel",
+		"Drizzle":            "Nieselregen",
+		"Rain":               "Regen",
+		"Showers":            "Schauer",
+		"Thunderstorm":       "Gewitter",
+		"Sleet":              "Schneeregen",
+		"Hail":               "Hagel",
+		"Snow":               "Schnee",
+		"Blizzard":           "Schneesturm",
+
+		// Forecast tables
+		"Weather Forecast":       "Wettervorhersage",
+		"Hourly Forecast for %s": "Stündliche Vorhersage für %s",
+		"Weather conditions:":    "Wetterlage:",
+		"Date":                   "Datum",
+		"Condition":              "Wetterlage",
+		"Max":                    "Max",
+		"Min":                    "Min",
+		"Sunrise":                "Sonnenaufgang",
+		"Sunset":                 "Sonnenuntergang",
+		"Time":                   "Zeit",
+		"Temp":                   "Temp.",
+		"Rain Chance":            "Regenrisiko",
+
+		// Charts and their spoken summaries
+		"Temperature Trend (24 hours)":                          "Temperaturverlauf (24 Stunden)",
+		"Precipitation Chance (24 hours)":                       "Niederschlagsrisiko (24 Stunden)",
+		"Temperature %s from %.0f°C at %s":                      "Die Temperatur %s von %.0f°C um %s",
+		"Temperature holds steady around %.0f°C from %s to %s.": "Die Temperatur bleibt von %[2]s bis %[3]s konstant bei etwa %.0[1]f°C.",
+		"rises":                     "steigt",
+		"falls":                     "fällt",
+		"holds":                     "bleibt",
+		"then %s":                   "dann %s",
+		"to %.0f°C at %s":           "auf %.0f°C um %s",
+		"to a peak of %.0f°C at %s": "auf einen Höchstwert von %.0f°C um %s",
+		"to a low of %.0f°C at %s":  "auf einen Tiefstwert von %.0f°C um %s",
+		"No rain expected.":         "Kein Regen erwartet.",
+		"at %s":                     "um %s",
+		"from %s to %s":             "von %s bis %s",
+		"and":                       "und",
+		"Chance of rain stays below %d%%, peaking at %d%% at %s.":       "Das Regenrisiko bleibt unter %d%% und erreicht höchstens %d%% um %s.",
+		"Chance of rain peaks at %d%% at %s, and is %d%% or higher %s.": "Das Regenrisiko erreicht %d%% um %s und liegt %[4]s bei %[3]d%% oder mehr.",
+
+		// Placeholders
+		"No forecast data available":                                                       "Keine Vorhersagedaten verfügbar",
+		"No hourly data available":                                                         "Keine stündlichen Daten verfügbar",
+		"No hourly temperatures available":                                                 "Keine stündlichen Temperaturen verfügbar",
+		"No hourly precipitation data available":                                           "Keine stündlichen Niederschlagsdaten verfügbar",
+		"No history available for this date":                                               "Für dieses Datum ist kein Verlauf verfügbar",
+		"Nothing recorded yet; run 'illapaca log' to start recording":                      "Noch nichts aufgezeichnet; starten Sie die Aufzeichnung mit 'illapaca log'",
+		"Not enough recorded days yet; run 'illapaca climate fetch' or 'illapaca log'":     "Noch nicht genug aufgezeichnete Tage; führen Sie 'illapaca climate fetch' oder 'illapaca log' aus",
+		"Not enough recorded forecasts and observations yet; run 'illapaca log' regularly": "Noch nicht genug Vorhersagen und Beobachtungen; führen Sie regelmäßig 'illapaca log' aus",
+
+		// Alerts
+		"High temperature (%.1f°C) exceeds threshold (%.1f°C)":        "Hohe Temperatur (%.1f°C) überschreitet den Grenzwert (%.1f°C)",
+		"Low temperature (%.1f°C) below threshold (%.1f°C)":           "Niedrige Temperatur (%.1f°C) unter dem Grenzwert (%.1f°C)",
+		"High wind speed (%.1f km/h) exceeds threshold (%.1f km/h)":   "Starker Wind (%.1f km/h) überschreitet den Grenzwert (%.1f km/h)",
+		"High chance of rain (%d%%) on %s exceeds threshold (%.0f%%)": "Hohes Regenrisiko (%d%%) am %s überschreitet den Grenzwert (%.0f%%)",
+		"Alert Threshold Settings:":                                   "Warngrenzwerte:",
+		"High Temperature: %.1f°C":                                    "Hohe Temperatur: %.1f°C",
+		"Low Temperature: %.1f°C":                                     "Niedrige Temperatur: %.1f°C",
+		"Precipitation Chance: %.0f%%":                                "Niederschlagsrisiko: %.0f%%",
+		"Wind Speed: %.1f km/h":                                       "Windgeschwindigkeit: %.1f km/h",
+
+		// Dashboards
+		"ILLAPA WEATHER DASHBOARD":                   "ILLAPA WETTER-DASHBOARD",
+		"ILLAPA WEATHER":                             "ILLAPA WETTER",
+		"(Feels: %.1f°C)":                            "(Gefühlt: %.1f°C)",
+		"Wind: %.1f km/h %s":                         "Wind: %.1f km/h %s",
+		"Hum: %d%%":                                  "Feuchte: %d%%",
+		"3-Day Forecast:":                            "3-Tage-Vorhersage:",
+		"%d weather alerts detected":                 "%d Wetterwarnungen erkannt",
+		"%s: %s %.1f°C/%.1f°C | Rain: %d%%":          "%s: %s %.1f°C/%.1f°C | Regen: %d%%",
+		"%s: %s, high %.1f°C, low %.1f°C, rain %d%%": "%s: %s, Höchstwert %.1f°C, Tiefstwert %.1f°C, Regen %d%%",
+
+		// Comparison
+		"Location Comparison: ":       "Ortsvergleich: ",
+		"vs":                          "vs.",
+		"Metric":                      "Messgröße",
+		"Difference":                  "Differenz",
+		"Temperature":                 "Temperatur",
+		"Feels Like":                  "Gefühlt",
+		"Humidity":                    "Luftfeuchte",
+		"Wind Speed":                  "Windgeschwindigkeit",
+		"Wind Direction":              "Windrichtung",
+		"Precipitation":               "Niederschlag",
+		"Visibility":                  "Sichtweite",
+		"Local Time":                  "Ortszeit",
+		"%s is %.1f°C warmer than %s": "%s ist %.1f°C wärmer als %s",
+		"%s is %.1f°C colder than %s": "%s ist %.1f°C kälter als %s",
+		"%s is more humid than %s":    "%s ist feuchter als %s",
+		"%s is drier than %s":         "%s ist trockener als %s",
+		"%s is windier than %s":       "%s ist windiger als %s",
+		"%s is calmer than %s":        "%s ist ruhiger als %s",
+
+		// History, climate and accuracy
+		"Weather History: %s, %s":   "Wetterverlauf: %s, %s",
+		"Recorded History: %s":      "Aufgezeichneter Verlauf: %s",
+		"Avg":                       "Mittel",
+		"Precip":                    "Niederschl.",
+		"Max Wind":                  "Max. Wind",
+		"Readings":                  "Messungen",
+		"Yes":                       "Ja",
+		"No":                        "Nein",
+		"Climate for %s on %s":      "Klima für %s am %s",
+		"Normal:":                   "Normal:",
+		"Normal high:":              "Normales Maximum:",
+		"Normal low:":               "Normales Minimum:",
+		"Typical range:":            "Typischer Bereich:",
+		"Record high:":              "Wärmerekord:",
+		"Record low:":               "Kälterekord:",
+		"%.1f°C on %s":              "%.1f°C am %s",
+		"Based on %d recorded days": "Basierend auf %d aufgezeichneten Tagen",
+		"%.1f°C to %.1f°C (10th to 90th percentile, median %.1f°C)": "%.1f°C bis %.1f°C (10. bis 90. Perzentil, Median %.1f°C)",
+		"Forecast Accuracy: %s": "Vorhersagegenauigkeit: %s",
+		"Provider":              "Anbieter",
+		"Lead":                  "Vorlauf",
+		"Samples":               "Stichproben",
+		"Max MAE":               "Max MAE",
+		"Max Bias":              "Max Bias",
+		"Min MAE":               "Min MAE",
+		"Min Bias":              "Min Bias",
+		"Rain MAE":              "Regen MAE",
+		"Rain Bias":             "Regen Bias",
+		"Same day":              "Gleicher Tag",
+		"1 day":                 "1 Tag",
+		"%d days":               "%d Tage",
+		"%s has the lowest temperature error here (%.1f°C on average)": "%s hat hier den geringsten Temperaturfehler (im Mittel %.1f°C)",
+
+		// Wind
+		"Wind: %s, %s":                "Wind: %s, %s",
+		"%.1f km/h from the %s (%d°)": "%.1f km/h aus %s (%d°)",
+		"Gusts up to %.1f km/h":       "Böen bis %.1f km/h",
+		"Beaufort %d: %s":             "Beaufort %d: %s",
+		"Calm":                        "Windstille",
+		"Light air":                   "Leiser Zug",
+		"Light breeze":                "Leichte Brise",
+		"Gentle breeze":               "Schwache Brise",
+		"Moderate breeze":             "Mäßige Brise",
+		"Fresh breeze":                "Frische Brise",
+		"Strong breeze":               "Starker Wind",
+		"Near gale":                   "Steifer Wind",
+		"Gale":                        "Stürmischer Wind",
+		"Strong gale":                 "Sturm",
+		"Storm":                       "Schwerer Sturm",
+		"Violent storm":               "Orkanartiger Sturm",
+		"Hurricane force":             "Orkan",
+		"Sustained Wind and Gusts (next 24 hours)": "Mittelwind und Böen (nächste 24 Stunden)",
+		"No hourly wind data available":            "Keine stündlichen Winddaten verfügbar",
+		"%.0f / %.0f km/h":                         "%.0f / %.0f km/h",
+		"sustained":                                "Mittelwind",
+		"gusts":                                    "Böen",
+		"Sustained wind peaks at %.0f km/h at %s":  "Der Mittelwind erreicht %.0f km/h um %s",
+		"with gusts up to %.0f km/h at %s":         "mit Böen bis %.0f km/h um %s",
+		"Wind Rose (%s)":                           "Windrose (%s)",
+		"Calm all day.":                            "Den ganzen Tag windstill.",
+		"Wind by direction: %s.":                   "Wind nach Richtung: %s.",
+		"Mostly from the %s (%d%% of hours)":       "Meist aus %s (%d%% der Stunden)",
+		"Calm for %d hours":                        "Windstill während %d Stunden",
+
+		// Heatmaps
+		"Temperature by Hour":                   "Temperatur nach Stunde",
+		"Chance of Rain by Hour":                "Regenrisiko nach Stunde",
+		"Wind by Hour":                          "Wind nach Stunde",
+		"UV Index by Hour":                      "UV-Index nach Stunde",
+		"UV not available from %s":              "UV von %s nicht verfügbar",
+		"No hourly forecast data available":     "Keine stündliche Vorhersage verfügbar",
+		"%s: lowest %s at %s, highest %s at %s": "%s: am niedrigsten %s um %s, am höchsten %s um %s",
+		"Warmest dry hour: %s at %s, %.0f°C":    "Wärmste trockene Stunde: %s um %s, %.0f°C",
+
+		// Trends and favorites
+		"Next 24h:":                              "Nächste 24 h:",
+		"Next 24 hours: temperature %s, rain %s": "Nächste 24 Stunden: Temperatur %s, Regen %s",
+		"Temp (24h)":                             "Temp. 24 h",
+		"Rain (24h)":                             "Regen 24 h",
+		"%.0f°C to %.0f°C":                       "%.0f°C bis %.0f°C",
+		"up to %.0f%%":                           "bis %.0f%%",
+		"Favorite Locations":                     "Lieblingsorte",
+		"No favorite locations saved":            "Keine Lieblingsorte gespeichert",
+		"Location":                               "Ort",
+		"Now":                                    "Jetzt",
+		"High/Low":                               "Max/Min",
+
+		// Activity planning
+		"Running":                   "Laufen",
+		"Cycling":                   "Radfahren",
+		"Hiking":                    "Wandern",
+		"Photography":               "Fotografie",
+		"Laundry":                   "Wäsche",
+		"Barbecue":                  "Grillen",
+		"Best Times for %s: %s, %s": "Beste Zeiten für %s: %s, %s",
+		"Suggested Windows":         "Vorgeschlagene Zeitfenster",
+		"Hourly Scores":             "Bewertung pro Stunde",
+		"No hours score %d or more; the best is %s at %s, scoring %d": "Keine Stunde erreicht %d; am besten ist %s um %s mit %d",
+		"rain up to %d%%":                        "Regen bis %d%%",
+		"dry":                                    "trocken",
+		"wind up to %.0f km/h":                   "Wind bis %.0f km/h",
+		"%d. %s, %s to %s: score %d of 100, %s.": "%d. %s, %s bis %s: Bewertung %d von 100, %s.",
+		"%s: best %d at %s, worst %d at %s":      "%s: beste %d um %s, schlechteste %d um %s",
+
+		// Comfort and advice
+		"Comfort and Advice": "Komfort und Tipps",
+		"Dew point:":         "Taupunkt:",
+		"Heat index:":        "Hitzeindex:",
+		"Wind chill:":        "Windchill:",
+		"Humidex:":           "Humidex:",
+		"comfortable":        "angenehm",
+		"slightly humid":     "leicht schwül",
+		"humid":              "feucht",
+		"muggy":              "schwül",
+		"oppressive":         "drückend",
+		"Layers:":            "Kleidung:",
+		"Umbrella:":          "Schirm:",
+		"Sunscreen:":         "Sonnenschutz:",
+		"Hydration:":         "Trinken:",
+		"Heavy winter coat, hat, gloves and scarf":                    "Dicke Winterjacke, Mütze, Handschuhe und Schal",
+		"Winter coat, hat and gloves":                                 "Winterjacke, Mütze und Handschuhe",
+		"Warm jacket over a sweater":                                  "Warme Jacke über einem Pullover",
+		"Light jacket or sweater":                                     "Leichte Jacke oder Pullover",
+		"Long sleeves or a light layer":                               "Lange Ärmel oder eine leichte Schicht",
+		"T-shirt weather":                                             "T-Shirt-Wetter",
+		"Light, breathable clothing":                                  "Leichte, atmungsaktive Kleidung",
+		"add a windproof layer":                                       "dazu eine winddichte Schicht",
+		"Take one, it is raining":                                     "Mitnehmen, es regnet",
+		"Take one, %d%% chance of rain in the coming hours":           "Mitnehmen, %d%% Regenwahrscheinlichkeit in den nächsten Stunden",
+		"Worth packing, %d%% chance of rain in the coming hours":      "Lohnt sich, %d%% Regenwahrscheinlichkeit in den nächsten Stunden",
+		"Not needed":                                                  "Nicht nötig",
+		"SPF 50+, hat and sunglasses; avoid the midday sun (UV %.0f)": "LSF 50+, Hut und Sonnenbrille; Mittagssonne meiden (UV %.0f)",
+		"SPF 30+, hat and sunglasses (UV %.0f)":                       "LSF 30+, Hut und Sonnenbrille (UV %.0f)",
+		"SPF 30 if you are out for long (UV %.0f)":                    "LSF 30, wenn Sie länger draußen sind (UV %.0f)",
+		"Not needed (UV %.0f)":                                        "Nicht nötig (UV %.0f)",
+		"UV index not available from %s":                              "UV-Index von %s nicht verfügbar",
+		"Drink water often and rest in the shade (feels like %.0f°C)": "Oft trinken und im Schatten ausruhen (gefühlt %.0f°C)",
+		"Drink more water than usual (feels like %.0f°C)":             "Mehr trinken als sonst (gefühlt %.0f°C)",
+		"Carry a water bottle":                                        "Eine Wasserflasche mitnehmen",
+		"Drink as usual":                                              "Wie gewohnt trinken",
+
+		// Commute
+		"Commute: %s to %s": "Pendeln: %s nach %s",
+		"Leaving at %s, returning at %s, %.0f minutes each way": "Abfahrt um %s, Rückfahrt um %s, %.0f Minuten pro Strecke",
+		"No upcoming workday trips in the forecast":             "Keine anstehenden Werktagsfahrten in der Vorhersage",
+		"Day":                                "Tag",
+		"Trip":                               "Fahrt",
+		"Leave":                              "Abfahrt",
+		"Arrive":                             "Ankunft",
+		"Watch Out":                          "Achtung",
+		"Outbound":                           "Hinfahrt",
+		"Return":                             "Rückfahrt",
+		"Mon":                                "Mo",
+		"Tue":                                "Di",
+		"Wed":                                "Mi",
+		"Thu":                                "Do",
+		"Fri":                                "Fr",
+		"%s %s, %.0f°C, rain %d%%":           "%s %s, %.0f°C, Regen %d%%",
+		"Rain %d%%":                          "Regen %d%%",
+		"Wind %.0f km/h":                     "Wind %.0f km/h",
+		"The trip looks dry and calm":        "Die Fahrt bleibt trocken und ruhig",
+		"All %d trips look dry and calm":     "Alle %d Fahrten bleiben trocken und ruhig",
+		"%d of %d trips may be wet or windy": "%d von %d Fahrten könnten nass oder windig werden",
+		"%s %s, feels like %s":               "%s %s, gefühlt %s",
+		"Humidity %d%%, wind %s, rain %d%%":  "Luftfeuchte %d%%, Wind %s, Regen %d%%",
+	},
+}
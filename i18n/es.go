@@ -0,0 +1,309 @@
+package i18n
+
+var spanish = &Locale{
+	Tag:            "es",
+	Name:           "Español",
+	ProviderLang:   "es",
+	Decimal:        ",",
+	DateLayout:     "02/01/2006",
+	DayMonthLayout: "2 Jan",
+	ClockLayout:    "15:04",
+	Months:         [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+	messages: map[string]string{
+		// Current conditions
+		"Current Weather":            "Tiempo actual",
+		"Local time: %s":             "Hora local: %s",
+		"Condition: %s":              "Condición: %s",
+		"Temperature: ":              "Temperatura: ",
+		"Feels like: ":               "Sensación térmica: ",
+		"Wind:":                      "Viento:",
+		"Humidity:":                  "Humedad:",
+		"Precip:":                    "Precip.:",
+		"Visibility:":                "Visibilidad:",
+		"UV Index:":                  "Índice UV:",
+		"N":                          "N",
+		"E":                          "E",
+		"S":                          "S",
+		"W":                          "O",
+		"unusually warm":             "inusualmente cálido",
+		"unusually cold":             "inusualmente frío",
+		"Normal for %s":              "Normal para el %s",
+		"record":                     "récord",
+		"record high":                "máxima récord",
+		"record low":                 "mínima récord",
+		"vs Normal":                  "vs. normal",
+		"Weather alerts:":            "Alertas meteorológicas:",
+		"WEATHER ALERTS":             "ALERTAS METEOROLÓGICAS",
+		"%.1f°C above normal for %s": "%.1f°C por encima de lo normal para el %s",
+		"%.1f°C below normal for %s": "%.1f°C por debajo de lo normal para el %s",
+		"Record high for %s (previous %.1f°C in %s)": "Máxima récord para el %s (anterior %.1f°C en %s)",
+		"Record low for %s (previous %.1f°C in %s)":  "Mínima récord para el %s (anterior %.1f°C en %s)",
+
+		// Conditions, for providers without the language
+		"Unknown conditions": "Condiciones desconocidas",
+		"Clear":              "Despejado",
+		"Partly cloudy":      "Parcialmente nublado",
+		"Cloudy":             "Nublado",
+		"Fog":                "Niebla",
+		"Drizzle":            "Llovizna",
+		"Rain":               "Lluvia",
+		"Showers":            "Chubascos",
+		"Thunderstorm":       "Tormenta",
+		"Sleet":              "Aguanieve",
+		"Hail":               "Granizo",
+		"Snow":               "Nieve",
+		"Blizzard":           "Ventisca",
+
+		// Forecast tables
+		"Weather Forecast":       "Pronóstico del tiempo",
+		"Hourly Forecast for %s": "Pronóstico por hora del %s",
+		"Weather conditions:":    "Condiciones:",
+		"Date":                   "Fecha",
+		"Condition":              "Condición",
+		"Max":                    "Máx.",
+		"Min":                    "Mín.",
+		"Sunrise":                "Amanecer",
+		"Sunset":                 "Atardecer",
+		"Time":                   "Hora",
+		"Temp":                   "Temp.",
+		"Rain Chance":            "Probabilidad",
+
+		// Charts and their spoken summaries
+		"Temperature Trend (24 hours)":                          "Evolución de la temperatura (24 horas)",
+		"Precipitation Chance (24 hours)":                       "Probabilidad de precipitación (24 horas)",
+		"Temperature %s from %.0f°C at %s":                      "La temperatura %s de %.0f°C a las %s",
+		"Temperature holds steady around %.0f°C from %s to %s.": "La temperatura se mantiene en torno a %.0f°C de %s a %s.",
+		"rises":                     "sube",
+		"falls":                     "baja",
+		"holds":                     "se mantiene",
+		"then %s":                   "luego %s",
+		"to %.0f°C at %s":           "a %.0f°C a las %s",
+		"to a peak of %.0f°C at %s": "a un máximo de %.0f°C a las %s",
+		"to a low of %.0f°C at %s":  "a un mínimo de %.0f°C a las %s",
+		"No rain expected.":         "No se espera lluvia.",
+		"at %s":                     "a las %s",
+		"from %s to %s":             "de %s a %s",
+		"and":                       "y",
+		"Chance of rain stays below %d%%, peaking at %d%% at %s.":       "La probabilidad de lluvia se mantiene por debajo del %d%%, con un máximo del %d%% a las %s.",
+		"Chance of rain peaks at %d%% at %s, and is %d%% or higher %s.": "La probabilidad de lluvia alcanza el %d%% a las %s, y es del %d%% o más %s.",
+
+		// Placeholders
+		"No forecast data available":                                                       "No hay datos de pronóstico",
+		"No hourly data available":                                                         "No hay datos por hora",
+		"No hourly temperatures available":                                                 "No hay temperaturas por hora",
+		"No hourly precipitation data available":                                           "No hay datos de precipitación por hora",
+		"No history available for this date":                                               "No hay historial para esta fecha",
+		"Nothing recorded yet; run 'illapaca log' to start recording":                      "Aún no hay registros; ejecute 'illapaca log' para empezar a registrar",
+		"Not enough recorded days yet; run 'illapaca climate fetch' or 'illapaca log'":     "Aún no hay suficientes días registrados; ejecute 'illapaca climate fetch' o 'illapaca log'",
+		"Not enough recorded forecasts and observations yet; run 'illapaca log' regularly": "Aún no hay suficientes pronósticos y observaciones; ejecute 'illapaca log' con regularidad",
+
+		// Alerts
+		"High temperature (%.1f°C) exceeds threshold (%.1f°C)":        "Temperatura alta (%.1f°C) supera el umbral (%.1f°C)",
+		"Low temperature (%.1f°C) below threshold (%.1f°C)":           "Temperatura baja (%.1f°C) por debajo del umbral (%.1f°C)",
+		"High wind speed (%.1f km/h) exceeds threshold (%.1f km/h)":   "Viento fuerte (%.1f km/h) supera el umbral (%.1f km/h)",
+		"High chance of rain (%d%%) on %s exceeds threshold (%.0f%%)": "Alta probabilidad de lluvia (%d%%) el %s supera el umbral (%.0f%%)",
+		"Alert Threshold Settings:":                                   "Umbrales de alerta:",
+		"High Temperature: %.1f°C":                                    "Temperatura alta: %.1f°C",
+		"Low Temperature: %.1f°C":                                     "Temperatura baja: %.1f°C",
+		"Precipitation Chance: %.0f%%":                                "Probabilidad de precipitación: %.0f%%",
+		"Wind Speed: %.1f km/h":                                       "Velocidad del viento: %.1f km/h",
+
+		// Dashboards
+		"ILLAPA WEATHER DASHBOARD":                   "PANEL DEL TIEMPO ILLAPA",
+		"ILLAPA WEATHER":                             "EL TIEMPO ILLAPA",
+		"(Feels: %.1f°C)":                            "(Sensación: %.1f°C)",
+		"Wind: %.1f km/h %s":                         "Viento: %.1f km/h %s",
+		"Hum: %d%%":                                  "Hum.: %d%%",
+		"3-Day Forecast:":                            "Pronóstico de 3 días:",
+		"%d weather alerts detected":                 "%d alertas meteorológicas detectadas",
+		"%s: %s %.1f°C/%.1f°C | Rain: %d%%":          "%s: %s %.1f°C/%.1f°C | Lluvia: %d%%",
+		"%s: %s, high %.1f°C, low %.1f°C, rain %d%%": "%s: %s, máxima %.1f°C, mínima %.1f°C, lluvia %d%%",
+
+		// Comparison
+		"Location Comparison: ":       "Comparación de lugares: ",
+		"vs":                          "vs.",
+		"Metric":                      "Medida",
+		"Difference":                  "Diferencia",
+		"Temperature":                 "Temperatura",
+		"Feels Like":                  "Sensación térmica",
+		"Humidity":                    "Humedad",
+		"Wind Speed":                  "Velocidad del viento",
+		"Wind Direction":              "Dirección del viento",
+		"Precipitation":               "Precipitación",
+		"Visibility":                  "Visibilidad",
+		"Local Time":                  "Hora local",
+		"%s is %.1f°C warmer than %s": "%s está %.1f°C más cálido que %s",
+		"%s is %.1f°C colder than %s": "%s está %.1f°C más frío que %s",
+		"%s is more humid than %s":    "%s es más húmedo que %s",
+		"%s is drier than %s":         "%s es más seco que %s",
+		"%s is windier than %s":       "%s tiene más viento que %s",
+		"%s is calmer than %s":        "%s está más en calma que %s",
+
+		// History, climate and accuracy
+		"Weather History: %s, %s":   "Historial del tiempo: %s, %s",
+		"Recorded History: %s":      "Historial registrado: %s",
+		"Avg":                       "Media",
+		"Precip":                    "Precip.",
+		"Max Wind":                  "Viento máx.",
+		"Readings":                  "Lecturas",
+		"Yes":                       "Sí",
+		"No":                        "No",
+		"Climate for %s on %s":      "Clima de %s el %s",
+		"Normal:":                   "Normal:",
+		"Normal high:":              "Máxima normal:",
+		"Normal low:":               "Mínima normal:",
+		"Typical range:":            "Rango típico:",
+		"Record high:":              "Máxima récord:",
+		"Record low:":               "Mínima récord:",
+		"%.1f°C on %s":              "%.1f°C el %s",
+		"Based on %d recorded days": "Basado en %d días registrados",
+		"%.1f°C to %.1f°C (10th to 90th percentile, median %.1f°C)": "%.1f°C a %.1f°C (percentil 10 a 90, mediana %.1f°C)",
+		"Forecast Accuracy: %s": "Precisión del pronóstico: %s",
+		"Provider":              "Proveedor",
+		"Lead":                  "Antelación",
+		"Samples":               "Muestras",
+		"Max MAE":               "EAM máx.",
+		"Max Bias":              "Sesgo máx.",
+		"Min MAE":               "EAM mín.",
+		"Min Bias":              "Sesgo mín.",
+		"Rain MAE":              "EAM lluvia",
+		"Rain Bias":             "Sesgo lluvia",
+		"Same day":              "Mismo día",
+		"1 day":                 "1 día",
+		"%d days":               "%d días",
+		"%s has the lowest temperature error here (%.1f°C on average)": "%s tiene el menor error de temperatura aquí (%.1f°C de media)",
+
+		// Wind
+		"Wind: %s, %s":                "Viento: %s, %s",
+		"%.1f km/h from the %s (%d°)": "%.1f km/h del %s (%d°)",
+		"Gusts up to %.1f km/h":       "Rachas de hasta %.1f km/h",
+		"Beaufort %d: %s":             "Beaufort %d: %s",
+		"Calm":                        "Calma",
+		"Light air":                   "Ventolina",
+		"Light breeze":                "Brisa muy débil",
+		"Gentle breeze":               "Brisa débil",
+		"Moderate breeze":             "Brisa moderada",
+		"Fresh breeze":                "Brisa fresca",
+		"Strong breeze":               "Brisa fuerte",
+		"Near gale":                   "Viento fuerte",
+		"Gale":                        "Temporal",
+		"Strong gale":                 "Temporal fuerte",
+		"Storm":                       "Temporal duro",
+		"Violent storm":               "Temporal muy duro",
+		"Hurricane force":             "Huracán",
+		"Sustained Wind and Gusts (next 24 hours)": "Viento sostenido y rachas (próximas 24 horas)",
+		"No hourly wind data available":            "No hay datos de viento por hora",
+		"%.0f / %.0f km/h":                         "%.0f / %.0f km/h",
+		"sustained":                                "sostenido",
+		"gusts":                                    "rachas",
+		"Sustained wind peaks at %.0f km/h at %s":  "El viento sostenido alcanza %.0f km/h a las %s",
+		"with gusts up to %.0f km/h at %s":         "con rachas de hasta %.0f km/h a las %s",
+		"Wind Rose (%s)":                           "Rosa de los vientos (%s)",
+		"Calm all day.":                            "Calma todo el día.",
+		"Wind by direction: %s.":                   "Viento por dirección: %s.",
+		"Mostly from the %s (%d%% of hours)":       "Sobre todo del %s (%d%% de las horas)",
+		"Calm for %d hours":                        "Calma durante %d horas",
+
+		// Heatmaps
+		"Temperature by Hour":                   "Temperatura por hora",
+		"Chance of Rain by Hour":                "Probabilidad de lluvia por hora",
+		"Wind by Hour":                          "Viento por hora",
+		"UV Index by Hour":                      "Índice UV por hora",
+		"UV not available from %s":              "UV no disponible en %s",
+		"No hourly forecast data available":     "No hay pronóstico por hora",
+		"%s: lowest %s at %s, highest %s at %s": "%s: mínimo %s a las %s, máximo %s a las %s",
+		"Warmest dry hour: %s at %s, %.0f°C":    "Hora seca más cálida: %s a las %s, %.0f°C",
+
+		// Trends and favorites
+		"Next 24h:":                              "Próximas 24 h:",
+		"Next 24 hours: temperature %s, rain %s": "Próximas 24 horas: temperatura %s, lluvia %s",
+		"Temp (24h)":                             "Temp. 24 h",
+		"Rain (24h)":                             "Lluvia 24 h",
+		"%.0f°C to %.0f°C":                       "%.0f°C a %.0f°C",
+		"up to %.0f%%":                           "hasta %.0f%%",
+		"Favorite Locations":                     "Ubicaciones favoritas",
+		"No favorite locations saved":            "No hay ubicaciones favoritas guardadas",
+		"Location":                               "Ubicación",
+		"Now":                                    "Ahora",
+		"High/Low":                               "Máx/Mín",
+
+		// Activity planning
+		"Running":                   "Correr",
+		"Cycling":                   "Ciclismo",
+		"Hiking":                    "Senderismo",
+		"Photography":               "Fotografía",
+		"Laundry":                   "Colada",
+		"Barbecue":                  "Parrillada",
+		"Best Times for %s: %s, %s": "Mejores momentos para %s: %s, %s",
+		"Suggested Windows":         "Franjas sugeridas",
+		"Hourly Scores":             "Puntuación por hora",
+		"No hours score %d or more; the best is %s at %s, scoring %d": "Ninguna hora llega a %d; la mejor es %s a las %s, con %d",
+		"rain up to %d%%":                        "lluvia hasta %d%%",
+		"dry":                                    "seco",
+		"wind up to %.0f km/h":                   "viento hasta %.0f km/h",
+		"%d. %s, %s to %s: score %d of 100, %s.": "%d. %s, de %s a %s: puntuación %d de 100, %s.",
+		"%s: best %d at %s, worst %d at %s":      "%s: mejor %d a las %s, peor %d a las %s",
+
+		// Comfort and advice
+		"Comfort and Advice": "Confort y consejos",
+		"Dew point:":         "Punto de rocío:",
+		"Heat index:":        "Índice de calor:",
+		"Wind chill:":        "Sensación por viento:",
+		"Humidex:":           "Humidex:",
+		"comfortable":        "agradable",
+		"slightly humid":     "algo húmedo",
+		"humid":              "húmedo",
+		"muggy":              "bochornoso",
+		"oppressive":         "sofocante",
+		"Layers:":            "Ropa:",
+		"Umbrella:":          "Paraguas:",
+		"Sunscreen:":         "Protector solar:",
+		"Hydration:":         "Hidratación:",
+		"Heavy winter coat, hat, gloves and scarf":                    "Abrigo grueso, gorro, guantes y bufanda",
+		"Winter coat, hat and gloves":                                 "Abrigo, gorro y guantes",
+		"Warm jacket over a sweater":                                  "Chaqueta abrigada sobre un suéter",
+		"Light jacket or sweater":                                     "Chaqueta ligera o suéter",
+		"Long sleeves or a light layer":                               "Manga larga o una capa ligera",
+		"T-shirt weather":                                             "Tiempo de camiseta",
+		"Light, breathable clothing":                                  "Ropa ligera y transpirable",
+		"add a windproof layer":                                       "añade una capa cortavientos",
+		"Take one, it is raining":                                     "Llévalo, está lloviendo",
+		"Take one, %d%% chance of rain in the coming hours":           "Llévalo, %d%% de probabilidad de lluvia en las próximas horas",
+		"Worth packing, %d%% chance of rain in the coming hours":      "Conviene llevarlo, %d%% de probabilidad de lluvia en las próximas horas",
+		"Not needed":                                                  "No hace falta",
+		"SPF 50+, hat and sunglasses; avoid the midday sun (UV %.0f)": "FPS 50+, sombrero y gafas de sol; evita el sol del mediodía (UV %.0f)",
+		"SPF 30+, hat and sunglasses (UV %.0f)":                       "FPS 30+, sombrero y gafas de sol (UV %.0f)",
+		"SPF 30 if you are out for long (UV %.0f)":                    "FPS 30 si vas a estar mucho tiempo fuera (UV %.0f)",
+		"Not needed (UV %.0f)":                                        "No hace falta (UV %.0f)",
+		"UV index not available from %s":                              "Índice UV no disponible en %s",
+		"Drink water often and rest in the shade (feels like %.0f°C)": "Bebe agua a menudo y descansa a la sombra (sensación de %.0f°C)",
+		"Drink more water than usual (feels like %.0f°C)":             "Bebe más agua de lo habitual (sensación de %.0f°C)",
+		"Carry a water bottle":                                        "Lleva una botella de agua",
+		"Drink as usual":                                              "Bebe como siempre",
+
+		// Commute
+		"Commute: %s to %s": "Trayecto: %s a %s",
+		"Leaving at %s, returning at %s, %.0f minutes each way": "Salida a las %s, vuelta a las %s, %.0f minutos por trayecto",
+		"No upcoming workday trips in the forecast":             "No hay trayectos laborables próximos en el pronóstico",
+		"Day":                                "Día",
+		"Trip":                               "Trayecto",
+		"Leave":                              "Salida",
+		"Arrive":                             "Llegada",
+		"Watch Out":                          "Atención",
+		"Outbound":                           "Ida",
+		"Return":                             "Vuelta",
+		"Mon":                                "lun",
+		"Tue":                                "mar",
+		"Wed":                                "mié",
+		"Thu":                                "jue",
+		"Fri":                                "vie",
+		"%s %s, %.0f°C, rain %d%%":           "%s %s, %.0f°C, lluvia %d%%",
+		"Rain %d%%":                          "Lluvia %d%%",
+		"Wind %.0f km/h":                     "Viento %.0f km/h",
+		"The trip looks dry and calm":        "El trayecto se ve seco y tranquilo",
+		"All %d trips look dry and calm":     "Los %d trayectos se ven secos y tranquilos",
+		"%d of %d trips may be wet or windy": "%d de %d trayectos pueden tener lluvia o viento",
+		"%s %s, feels like %s":               "%s %s, sensación de %s",
+		"Humidity %d%%, wind %s, rain %d%%":  "Humedad %d%%, viento %s, lluvia %d%%",
+	},
+}
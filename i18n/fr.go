@@ -0,0 +1,309 @@
+package i18n
+
+var french = &Locale{
+	Tag:            "fr",
+	Name:           "Français",
+	ProviderLang:   "fr",
+	Decimal:        ",",
+	DateLayout:     "02/01/2006",
+	DayMonthLayout: "2 Jan",
+	ClockLayout:    "15:04",
+	Months:         [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	messages: map[string]string{
+		// Current conditions
+		"Current Weather":            "Météo actuelle",
+		"Local time: %s":             "Heure locale : %s",
+		"Condition: %s":              "Conditions : %s",
+		"Temperature: ":              "Température : ",
+		"Feels like: ":               "Ressenti : ",
+		"Wind:":                      "Vent :",
+		"Humidity:":                  "Humidité :",
+		"Precip:":                    "Précip. :",
+		"Visibility:":                "Visibilité :",
+		"UV Index:":                  "Indice UV :",
+		"N":                          "N",
+		"E":                          "E",
+		"S":                          "S",
+		"W":                          "O",
+		"unusually warm":             "exceptionnellement chaud",
+		"unusually cold":             "exceptionnellement froid",
+		"Normal for %s":              "Normal pour le %s",
+		"record":                     "record",
+		"record high":                "record de chaleur",
+		"record low":                 "record de froid",
+		"vs Normal":                  "vs normale",
+		"Weather alerts:":            "Alertes météo :",
+		"WEATHER ALERTS":             "ALERTES MÉTÉO",
+		"%.1f°C above normal for %s": "%.1f°C au-dessus de la normale pour le %s",
+		"%.1f°C below normal for %s": "%.1f°C en dessous de la normale pour le %s",
+		"Record high for %s (previous %.1f°C in %s)": "Record de chaleur pour le %s (précédent %.1f°C en %s)",
+		"Record low for %s (previous %.1f°C in %s)":  "Record de froid pour le %s (précédent %.1f°C en %s)",
+
+		// Conditions, for providers without the language
+		"Unknown conditions": "Conditions inconnues",
+		"Clear":              "Dégagé",
+		"Partly cloudy":      "Partiellement nuageux",
+		"Cloudy":             "Nuageux",
+		"Fog":                "Brouillard",
+		"Drizzle":            "Bruine",
+		"Rain":               "Pluie",
+		"Showers":            "Averses",
+		"Thunderstorm":       "Orage",
+		"Sleet":              "Neige fondue",
+		"Hail":               "Grêle",
+		"Snow":               "Neige",
+		"Blizzard":           "Blizzard",
+
+		// Forecast tables
+		"Weather Forecast":       "Prévisions météo",
+		"Hourly Forecast for %s": "Prévisions horaires du %s",
+		"Weather conditions:":    "Conditions :",
+		"Date":                   "Date",
+		"Condition":              "Conditions",
+		"Max":                    "Max",
+		"Min":                    "Min",
+		"Sunrise":                "Lever",
+		"Sunset":                 "Coucher",
+		"Time":                   "Heure",
+		"Temp":                   "Temp.",
+		"Rain Chance":            "Risque de pluie",
+
+		// Charts and their spoken summaries
+		"Temperature Trend (24 hours)":                          "Évolution de la température (24 heures)",
+		"Precipitation Chance (24 hours)":                       "Risque de précipitations (24 heures)",
+		"Temperature %s from %.0f°C at %s":                      "La température %s de %.0f°C à %s",
+		"Temperature holds steady around %.0f°C from %s to %s.": "La température reste stable autour de %.0f°C de %s à %s.",
+		"rises":                     "monte",
+		"falls":                     "baisse",
+		"holds":                     "reste stable",
+		"then %s":                   "puis %s",
+		"to %.0f°C at %s":           "à %.0f°C à %s",
+		"to a peak of %.0f°C at %s": "jusqu'à un maximum de %.0f°C à %s",
+		"to a low of %.0f°C at %s":  "jusqu'à un minimum de %.0f°C à %s",
+		"No rain expected.":         "Pas de pluie prévue.",
+		"at %s":                     "à %s",
+		"from %s to %s":             "de %s à %s",
+		"and":                       "et",
+		"Chance of rain stays below %d%%, peaking at %d%% at %s.":       "Le risque de pluie reste inférieur à %d%%, avec un maximum de %d%% à %s.",
+		"Chance of rain peaks at %d%% at %s, and is %d%% or higher %s.": "Le risque de pluie culmine à %d%% à %s, et atteint %d%% ou plus %s.",
+
+		// Placeholders
+		"No forecast data available":                                                       "Aucune prévision disponible",
+		"No hourly data available":                                                         "Aucune donnée horaire disponible",
+		"No hourly temperatures available":                                                 "Aucune température horaire disponible",
+		"No hourly precipitation data available":                                           "Aucune donnée horaire de précipitations disponible",
+		"No history available for this date":                                               "Aucun historique pour cette date",
+		"Nothing recorded yet; run 'illapaca log' to start recording":                      "Rien d'enregistré pour l'instant ; lancez 'illapaca log' pour commencer",
+		"Not enough recorded days yet; run 'illapaca climate fetch' or 'illapaca log'":     "Pas encore assez de jours enregistrés ; lancez 'illapaca climate fetch' ou 'illapaca log'",
+		"Not enough recorded forecasts and observations yet; run 'illapaca log' regularly": "Pas encore assez de prévisions et d'observations ; lancez 'illapaca log' régulièrement",
+
+		// Alerts
+		"High temperature (%.1f°C) exceeds threshold (%.1f°C)":        "Température élevée (%.1f°C) au-dessus du seuil (%.1f°C)",
+		"Low temperature (%.1f°C) below threshold (%.1f°C)":           "Température basse (%.1f°C) en dessous du seuil (%.1f°C)",
+		"High wind speed (%.1f km/h) exceeds threshold (%.1f km/h)":   "Vent fort (%.1f km/h) au-dessus du seuil (%.1f km/h)",
+		"High chance of rain (%d%%) on %s exceeds threshold (%.0f%%)": "Risque de pluie élevé (%d%%) le %s au-dessus du seuil (%.0f%%)",
+		"Alert Threshold Settings:":                                   "Seuils d'alerte :",
+		"High Temperature: %.1f°C":                                    "Température élevée : %.1f°C",
+		"Low Temperature: %.1f°C":                                     "Température basse : %.1f°C",
+		"Precipitation Chance: %.0f%%":                                "Risque de précipitations : %.0f%%",
+		"Wind Speed: %.1f km/h":                                       "Vitesse du vent : %.1f km/h",
+
+		// Dashboards
+		"ILLAPA WEATHER DASHBOARD":                   "TABLEAU DE BORD MÉTÉO ILLAPA",
+		"ILLAPA WEATHER":                             "MÉTÉO ILLAPA",
+		"(Feels: %.1f°C)":                            "(Ressenti : %.1f°C)",
+		"Wind: %.1f km/h %s":                         "Vent : %.1f km/h %s",
+		"Hum: %d%%":                                  "Hum. : %d%%",
+		"3-Day Forecast:":                            "Prévisions sur 3 jours :",
+		"%d weather alerts detected":                 "%d alertes météo détectées",
+		"%s: %s %.1f°C/%.1f°C | Rain: %d%%":          "%s : %s %.1f°C/%.1f°C | Pluie : %d%%",
+		"%s: %s, high %.1f°C, low %.1f°C, rain %d%%": "%s : %s, maximum %.1f°C, minimum %.1f°C, pluie %d%%",
+
+		// Comparison
+		"Location Comparison: ":       "Comparaison de lieux : ",
+		"vs":                          "vs",
+		"Metric":                      "Mesure",
+		"Difference":                  "Différence",
+		"Temperature":                 "Température",
+		"Feels Like":                  "Ressenti",
+		"Humidity":                    "Humidité",
+		"Wind Speed":                  "Vitesse du vent",
+		"Wind Direction":              "Direction du vent",
+		"Precipitation":               "Précipitations",
+		"Visibility":                  "Visibilité",
+		"Local Time":                  "Heure locale",
+		"%s is %.1f°C warmer than %s": "Il fait %.1[2]f°C de plus à %[1]s qu'à %[3]s",
+		"%s is %.1f°C colder than %s": "Il fait %.1[2]f°C de moins à %[1]s qu'à %[3]s",
+		"%s is more humid than %s":    "%s est plus humide que %s",
+		"%s is drier than %s":         "%s est plus sec que %s",
+		"%s is windier than %s":       "%s est plus venteux que %s",
+		"%s is calmer than %s":        "%s est plus calme que %s",
+
+		// History, climate and accuracy
+		"Weather History: %s, %s":   "Historique météo : %s, %s",
+		"Recorded History: %s":      "Historique enregistré : %s",
+		"Avg":                       "Moy.",
+		"Precip":                    "Précip.",
+		"Max Wind":                  "Vent max",
+		"Readings":                  "Relevés",
+		"Yes":                       "Oui",
+		"No":                        "Non",
+		"Climate for %s on %s":      "Climat de %s le %s",
+		"Normal:":                   "Normale :",
+		"Normal high:":              "Maximale normale :",
+		"Normal low:":               "Minimale normale :",
+		"Typical range:":            "Plage habituelle :",
+		"Record high:":              "Record de chaleur :",
+		"Record low:":               "Record de froid :",
+		"%.1f°C on %s":              "%.1f°C le %s",
+		"Based on %d recorded days": "D'après %d jours enregistrés",
+		"%.1f°C to %.1f°C (10th to 90th percentile, median %.1f°C)": "%.1f°C à %.1f°C (10e au 90e centile, médiane %.1f°C)",
+		"Forecast Accuracy: %s": "Précision des prévisions : %s",
+		"Provider":              "Fournisseur",
+		"Lead":                  "Échéance",
+		"Samples":               "Échantillons",
+		"Max MAE":               "EAM max",
+		"Max Bias":              "Biais max",
+		"Min MAE":               "EAM min",
+		"Min Bias":              "Biais min",
+		"Rain MAE":              "EAM pluie",
+		"Rain Bias":             "Biais pluie",
+		"Same day":              "Jour même",
+		"1 day":                 "1 jour",
+		"%d days":               "%d jours",
+		"%s has the lowest temperature error here (%.1f°C on average)": "%s a la plus faible erreur de température ici (%.1f°C en moyenne)",
+
+		// Wind
+		"Wind: %s, %s":                "Vent : %s, %s",
+		"%.1f km/h from the %s (%d°)": "%.1f km/h de %s (%d°)",
+		"Gusts up to %.1f km/h":       "Rafales jusqu'à %.1f km/h",
+		"Beaufort %d: %s":             "Beaufort %d : %s",
+		"Calm":                        "Calme",
+		"Light air":                   "Très légère brise",
+		"Light breeze":                "Légère brise",
+		"Gentle breeze":               "Petite brise",
+		"Moderate breeze":             "Jolie brise",
+		"Fresh breeze":                "Bonne brise",
+		"Strong breeze":               "Vent frais",
+		"Near gale":                   "Grand frais",
+		"Gale":                        "Coup de vent",
+		"Strong gale":                 "Fort coup de vent",
+		"Storm":                       "Tempête",
+		"Violent storm":               "Violente tempête",
+		"Hurricane force":             "Ouragan",
+		"Sustained Wind and Gusts (next 24 hours)": "Vent moyen et rafales (24 prochaines heures)",
+		"No hourly wind data available":            "Aucune donnée de vent horaire disponible",
+		"%.0f / %.0f km/h":                         "%.0f / %.0f km/h",
+		"sustained":                                "vent moyen",
+		"gusts":                                    "rafales",
+		"Sustained wind peaks at %.0f km/h at %s":  "Le vent moyen culmine à %.0f km/h à %s",
+		"with gusts up to %.0f km/h at %s":         "avec des rafales jusqu'à %.0f km/h à %s",
+		"Wind Rose (%s)":                           "Rose des vents (%s)",
+		"Calm all day.":                            "Calme toute la journée.",
+		"Wind by direction: %s.":                   "Vent par direction : %s.",
+		"Mostly from the %s (%d%% of hours)":       "Surtout de %s (%d%% des heures)",
+		"Calm for %d hours":                        "Calme pendant %d heures",
+
+		// Heatmaps
+		"Temperature by Hour":                   "Température par heure",
+		"Chance of Rain by Hour":                "Risque de pluie par heure",
+		"Wind by Hour":                          "Vent par heure",
+		"UV Index by Hour":                      "Indice UV par heure",
+		"UV not available from %s":              "UV non disponible chez %s",
+		"No hourly forecast data available":     "Aucune prévision horaire disponible",
+		"%s: lowest %s at %s, highest %s at %s": "%s : minimum %s à %s, maximum %s à %s",
+		"Warmest dry hour: %s at %s, %.0f°C":    "Heure sèche la plus chaude : %s à %s, %.0f°C",
+
+		// Trends and favorites
+		"Next 24h:":                              "24 h à venir :",
+		"Next 24 hours: temperature %s, rain %s": "24 heures à venir : température %s, pluie %s",
+		"Temp (24h)":                             "Temp. 24 h",
+		"Rain (24h)":                             "Pluie 24 h",
+		"%.0f°C to %.0f°C":                       "%.0f°C à %.0f°C",
+		"up to %.0f%%":                           "jusqu'à %.0f%%",
+		"Favorite Locations":                     "Lieux favoris",
+		"No favorite locations saved":            "Aucun lieu favori enregistré",
+		"Location":                               "Lieu",
+		"Now":                                    "Maintenant",
+		"High/Low":                               "Max/Min",
+
+		// Activity planning
+		"Running":                   "Course à pied",
+		"Cycling":                   "Vélo",
+		"Hiking":                    "Randonnée",
+		"Photography":               "Photographie",
+		"Laundry":                   "Lessive",
+		"Barbecue":                  "Barbecue",
+		"Best Times for %s: %s, %s": "Meilleurs moments pour %s : %s, %s",
+		"Suggested Windows":         "Créneaux suggérés",
+		"Hourly Scores":             "Scores par heure",
+		"No hours score %d or more; the best is %s at %s, scoring %d": "Aucune heure n'atteint %d ; la meilleure est %s à %s, avec %d",
+		"rain up to %d%%":                        "pluie jusqu'à %d%%",
+		"dry":                                    "sec",
+		"wind up to %.0f km/h":                   "vent jusqu'à %.0f km/h",
+		"%d. %s, %s to %s: score %d of 100, %s.": "%d. %s, de %s à %s : score %d sur 100, %s.",
+		"%s: best %d at %s, worst %d at %s":      "%s : meilleur %d à %s, pire %d à %s",
+
+		// Comfort and advice
+		"Comfort and Advice": "Confort et conseils",
+		"Dew point:":         "Point de rosée :",
+		"Heat index:":        "Indice de chaleur :",
+		"Wind chill:":        "Refroidissement éolien :",
+		"Humidex:":           "Humidex :",
+		"comfortable":        "agréable",
+		"slightly humid":     "légèrement humide",
+		"humid":              "humide",
+		"muggy":              "lourd",
+		"oppressive":         "étouffant",
+		"Layers:":            "Vêtements :",
+		"Umbrella:":          "Parapluie :",
+		"Sunscreen:":         "Crème solaire :",
+		"Hydration:":         "Hydratation :",
+		"Heavy winter coat, hat, gloves and scarf":                    "Gros manteau d'hiver, bonnet, gants et écharpe",
+		"Winter coat, hat and gloves":                                 "Manteau d'hiver, bonnet et gants",
+		"Warm jacket over a sweater":                                  "Veste chaude sur un pull",
+		"Light jacket or sweater":                                     "Veste légère ou pull",
+		"Long sleeves or a light layer":                               "Manches longues ou une couche légère",
+		"T-shirt weather":                                             "Temps à t-shirt",
+		"Light, breathable clothing":                                  "Vêtements légers et respirants",
+		"add a windproof layer":                                       "ajoutez une couche coupe-vent",
+		"Take one, it is raining":                                     "Prenez-le, il pleut",
+		"Take one, %d%% chance of rain in the coming hours":           "Prenez-le, %d%% de risque de pluie dans les prochaines heures",
+		"Worth packing, %d%% chance of rain in the coming hours":      "Utile à emporter, %d%% de risque de pluie dans les prochaines heures",
+		"Not needed":                                                  "Inutile",
+		"SPF 50+, hat and sunglasses; avoid the midday sun (UV %.0f)": "SPF 50+, chapeau et lunettes de soleil ; évitez le soleil de midi (UV %.0f)",
+		"SPF 30+, hat and sunglasses (UV %.0f)":                       "SPF 30+, chapeau et lunettes de soleil (UV %.0f)",
+		"SPF 30 if you are out for long (UV %.0f)":                    "SPF 30 si vous restez longtemps dehors (UV %.0f)",
+		"Not needed (UV %.0f)":                                        "Inutile (UV %.0f)",
+		"UV index not available from %s":                              "Indice UV non disponible chez %s",
+		"Drink water often and rest in the shade (feels like %.0f°C)": "Buvez souvent et reposez-vous à l'ombre (ressenti %.0f°C)",
+		"Drink more water than usual (feels like %.0f°C)":             "Buvez plus que d'habitude (ressenti %.0f°C)",
+		"Carry a water bottle":                                        "Emportez une gourde",
+		"Drink as usual":                                              "Buvez comme d'habitude",
+
+		// Commute
+		"Commute: %s to %s": "Trajet : %s à %s",
+		"Leaving at %s, returning at %s, %.0f minutes each way": "Départ à %s, retour à %s, %.0f minutes par trajet",
+		"No upcoming workday trips in the forecast":             "Aucun trajet de jour ouvré à venir dans les prévisions",
+		"Day":                                "Jour",
+		"Trip":                               "Trajet",
+		"Leave":                              "Départ",
+		"Arrive":                             "Arrivée",
+		"Watch Out":                          "Attention",
+		"Outbound":                           "Aller",
+		"Return":                             "Retour",
+		"Mon":                                "lun",
+		"Tue":                                "mar",
+		"Wed":                                "mer",
+		"Thu":                                "jeu",
+		"Fri":                                "ven",
+		"%s %s, %.0f°C, rain %d%%":           "%s %s, %.0f°C, pluie %d%%",
+		"Rain %d%%":                          "Pluie %d%%",
+		"Wind %.0f km/h":                     "Vent %.0f km/h",
+		"The trip looks dry and calm":        "Le trajet s'annonce sec et calme",
+		"All %d trips look dry and calm":     "Les %d trajets s'annoncent secs et calmes",
+		"%d of %d trips may be wet or windy": "%d trajets sur %d risquent pluie ou vent",
+		"%s %s, feels like %s":               "%s %s, ressenti %s",
+		"Humidity %d%%, wind %s, rain %d%%":  "Humidité %d%%, vent %s, pluie %d%%",
+	},
+}
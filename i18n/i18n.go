@@ -0,0 +1,178 @@
+// Package i18n translates Illapaca's output. Messages are looked up by
+// their English text, so untranslated messages fall back to English, and
+// numbers and dates are formatted the way each language writes them.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Locale is a language's message catalog and formatting conventions
+type Locale struct {
+	// Tag is the ISO 639 language code, e.g. "es"
+	Tag string
+
+	// Name is the language's name in itself
+	Name string
+
+	// ProviderLang is the code weather providers use for the language, or
+	// empty if they do not support it and conditions are translated here
+	ProviderLang string
+
+	// Decimal separates the integer and fractional parts of numbers
+	Decimal string
+
+	// DateLayout formats a date, e.g. "02/01/2006"
+	DateLayout string
+
+	// DayMonthLayout formats a day of the year, e.g. "2 Jan"
+	DayMonthLayout string
+
+	// ClockLayout formats a time of day, e.g. "15:04"
+	ClockLayout string
+
+	// Months are the abbreviated month names, January first, replacing
+	// the English ones in layouts that use "Jan"
+	Months [12]string
+
+	messages map[string]string
+}
+
+// English is the untranslated locale
+var English = &Locale{
+	Tag:            "en",
+	Name:           "English",
+	ProviderLang:   "en",
+	Decimal:        ".",
+	DateLayout:     "2006-01-02",
+	DayMonthLayout: "Jan 2",
+	ClockLayout:    "03:04 PM",
+}
+
+// locales are the available locales by tag
+var locales = map[string]*Locale{
+	"en": English,
+	"es": spanish,
+	"fr": french,
+	"de": german,
+	"qu": quechua,
+}
+
+// Tags returns the available language codes, sorted
+func Tags() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Lookup returns the locale for a language code. POSIX locale names such
+// as "es_PE.UTF-8" and tags such as "fr-CA" select their language.
+func Lookup(name string) (*Locale, error) {
+	if l, ok := locales[language(name)]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("unsupported language %q (choose from %s)", name, strings.Join(Tags(), ", "))
+}
+
+// FromEnv returns the locale named by LC_ALL, LC_MESSAGES or LANG, in that
+// order, or English if none names an available language
+func FromEnv() *Locale {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		if l, ok := locales[language(value)]; ok {
+			return l
+		}
+		return English
+	}
+	return English
+}
+
+// language extracts the lower-case language code from a locale name
+func language(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(name, "_-.@"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// T translates a message, or returns it unchanged if it has no translation
+func (l *Locale) T(msg string) string {
+	if s, ok := l.messages[msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Sprintf translates format and formats it with args, writing float
+// arguments with the locale's decimal separator. Translations may reorder
+// arguments with explicit indexes such as %[2]s.
+func (l *Locale) Sprintf(format string, args ...interface{}) string {
+	if l.Decimal != "." {
+		for i, arg := range args {
+			if f, ok := arg.(float64); ok {
+				args[i] = number{f, l.Decimal}
+			}
+		}
+	}
+	return fmt.Sprintf(l.T(format), args...)
+}
+
+// Date formats a date in the locale's layout
+func (l *Locale) Date(t time.Time) string {
+	return l.format(t, l.DateLayout)
+}
+
+// DayMonth formats a day of the year in the locale's layout, e.g. "17 oct"
+func (l *Locale) DayMonth(t time.Time) string {
+	return l.format(t, l.DayMonthLayout)
+}
+
+// Clock formats a time of day in the locale's layout
+func (l *Locale) Clock(t time.Time) string {
+	return l.format(t, l.ClockLayout)
+}
+
+// format formats t with layout, substituting the locale's month names
+func (l *Locale) format(t time.Time, layout string) string {
+	s := t.Format(layout)
+	if l.Months[0] != "" && strings.Contains(layout, "Jan") {
+		s = strings.Replace(s, t.Format("Jan"), l.Months[t.Month()-1], 1)
+	}
+	return s
+}
+
+// number formats a float with another decimal separator
+type number struct {
+	v       float64
+	decimal string
+}
+
+// Format implements fmt.Formatter, honoring the flags, width and precision
+// of the verb
+func (n number) Format(f fmt.State, verb rune) {
+	spec := "%"
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			spec += string(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		spec += fmt.Sprint(width)
+	}
+	if prec, ok := f.Precision(); ok {
+		spec += "." + fmt.Sprint(prec)
+	}
+	spec += string(verb)
+	fmt.Fprint(f, strings.Replace(fmt.Sprintf(spec, n.v), ".", n.decimal, 1))
+}
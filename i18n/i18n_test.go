@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// verbs maps each argument a format string uses, by index, to its verb
+func verbs(format string) map[int]byte {
+	used := map[int]byte{}
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(format) && format[i] == '%' {
+			continue
+		}
+		for i < len(format) && strings.IndexByte("+-# 0123456789.[]", format[i]) >= 0 {
+			if format[i] == '[' {
+				end := strings.IndexByte(format[i:], ']')
+				n, _ := strconv.Atoi(format[i+1 : i+end])
+				arg = n - 1
+				i += end
+			}
+			i++
+		}
+		if i < len(format) {
+			used[arg] = format[i]
+			arg++
+		}
+	}
+	return used
+}
+
+func TestCatalogs(t *testing.T) {
+	var keys []string
+	for key := range spanish.messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, tag := range Tags() {
+		l := locales[tag]
+		if l == English {
+			continue
+		}
+		if len(l.messages) != len(keys) {
+			t.Errorf("%s has %d messages, es has %d", tag, len(l.messages), len(keys))
+		}
+		for _, key := range keys {
+			msg, ok := l.messages[key]
+			if !ok {
+				t.Errorf("%s is missing %q", tag, key)
+				continue
+			}
+			if want, got := verbs(key), verbs(msg); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s %q: verbs %v, want %v", tag, msg, got, want)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for name, want := range map[string]string{
+		"es":          "es",
+		"es_PE.UTF-8": "es",
+		"fr-CA":       "fr",
+		"DE":          "de",
+		"qu_PE":       "qu",
+		"en_US.UTF-8": "en",
+	} {
+		l, err := Lookup(name)
+		if err != nil || l.Tag != want {
+			t.Errorf("Lookup(%q) = %v, %v, want %s", name, l, err, want)
+		}
+	}
+	if _, err := Lookup("tlh"); err == nil {
+		t.Error("unsupported language was found")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	if l := FromEnv(); l.Tag != "de" {
+		t.Errorf("LANG=de_DE.UTF-8 gave %s", l.Tag)
+	}
+
+	t.Setenv("LC_ALL", "C")
+	if l := FromEnv(); l != English {
+		t.Errorf("LC_ALL=C gave %s", l.Tag)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	if got := spanish.Sprintf("Wind: %.1f km/h %s", 13.0, "SO"); got != "Viento: 13,0 km/h SO" {
+		t.Errorf("es wind = %q", got)
+	}
+	if got := german.Sprintf("%6.2f|%-6.1f|%+.1f", 1.5, 2.25, 3.0); got != "  1,50|2,2   |+3,0" {
+		t.Errorf("de padding = %q", got)
+	}
+	if got := french.Sprintf("%s is %.1f°C warmer than %s", "Lima", 4.5, "Cusco"); got != "Il fait 4,5°C de plus à Lima qu'à Cusco" {
+		t.Errorf("fr reordered = %q", got)
+	}
+	if got := English.Sprintf("%.1f°C", 16.25); got != fmt.Sprintf("%.1f°C", 16.25) {
+		t.Errorf("en = %q", got)
+	}
+
+	day := time.Date(2025, 10, 17, 15, 4, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		l               *Locale
+		date, dm, clock string
+	}{
+		{English, "2025-10-17", "Oct 17", "03:04 PM"},
+		{spanish, "17/10/2025", "17 oct", "15:04"},
+		{german, "17.10.2025", "17. Okt.", "15:04"},
+		{quechua, "17/10/2025", "17 Uma Raymi", "15:04"},
+	} {
+		if got := tt.l.Date(day); got != tt.date {
+			t.Errorf("%s date = %q, want %q", tt.l.Tag, got, tt.date)
+		}
+		if got := tt.l.DayMonth(day); got != tt.dm {
+			t.Errorf("%s day = %q, want %q", tt.l.Tag, got, tt.dm)
+		}
+		if got := tt.l.Clock(day); got != tt.clock {
+			t.Errorf("%s clock = %q, want %q", tt.l.Tag, got, tt.clock)
+		}
+	}
+}
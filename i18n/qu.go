@@ -0,0 +1,312 @@
+package i18n
+
+// quechua is Southern Quechua. Weather providers do not offer it, so
+// conditions are translated from their kind.
+var quechua = &Locale{
+	Tag:            "qu",
+	Name:           "Runasimi",
+	ProviderLang:   "",
+	Decimal:        ",",
+	DateLayout:     "02/01/2006",
+	DayMonthLayout: "2 Jan",
+	ClockLayout:    "15:04",
+	Months: [12]string{"Qhapaq Raymi", "Hatun Puquy", "Pawqar Waray", "Ayriway", "Aymuray", "Inti Raymi",
+		"Anta Sitwa", "Qhapaq Sitwa", "Quya Raymi", "Uma Raymi", "Aya Marq'ay", "Qhapaq Inti Raymi"},
+	messages: map[string]string{
+		// Current conditions
+		"Current Weather":            "Kunan pacha",
+		"Local time: %s":             "Kaypi ura: %s",
+		"Condition: %s":              "Imayna: %s",
+		"Temperature: ":              "Q'uñi: ",
+		"Feels like: ":               "Musyakun: ",
+		"Wind:":                      "Wayra:",
+		"Humidity:":                  "Huq'u:",
+		"Precip:":                    "Para:",
+		"Visibility:":                "Rikuy:",
+		"UV Index:":                  "UV tupu:",
+		"N":                          "N",
+		"E":                          "E",
+		"S":                          "S",
+		"W":                          "W",
+		"unusually warm":             "mana hina q'uñi",
+		"unusually cold":             "mana hina chiri",
+		"Normal for %s":              "%s p'unchaypaq hina",
+		"record":                     "aswan",
+		"record high":                "aswan q'uñi",
+		"record low":                 "aswan chiri",
+		"vs Normal":                  "Sapa kutiman",
+		"Weather alerts:":            "Pacha yuyachiykuna:",
+		"WEATHER ALERTS":             "PACHA YUYACHIYKUNA",
+		"%.1f°C above normal for %s": "%[2]s p'unchaypaq %.1[1]f°C aswan q'uñi",
+		"%.1f°C below normal for %s": "%[2]s p'unchaypaq %.1[1]f°C aswan chiri",
+		"Record high for %s (previous %.1f°C in %s)": "%s p'unchaypaq aswan q'uñi (ñawpaq %.1f°C, %s watapi)",
+		"Record low for %s (previous %.1f°C in %s)":  "%s p'unchaypaq aswan chiri (ñawpaq %.1f°C, %s watapi)",
+
+		// Conditions, for providers without the language
+		"Unknown conditions": "Mana yachasqa",
+		"Clear":              "Ch'uya",
+		"Partly cloudy":      "Pisi phuyu",
+		"Cloudy":             "Phuyusqa",
+		"Fog":                "Pampa phuyu",
+		"Drizzle":            "Iphu",
+		"Rain":               "Para",
+		"Showers":            "Pisi para",
+		"Thunderstorm":       "Illapa para",
+		"Sleet":              "Para rit'iwan",
+		"Hail":               "Chikchi",
+		"Snow":               "Rit'i",
+		"Blizzard":           "Rit'i wayra",
+
+		// Forecast tables
+		"Weather Forecast":       "Pacha willakuy",
+		"Hourly Forecast for %s": "%s p'unchaypa urankunan",
+		"Weather conditions:":    "Imayna:",
+		"Date":                   "P'unchay",
+		"Condition":              "Imayna",
+		"Max":                    "Aswan",
+		"Min":                    "Aslla",
+		"Sunrise":                "Inti lluqsiy",
+		"Sunset":                 "Inti haykuy",
+		"Time":                   "Ura",
+		"Temp":                   "Q'uñi",
+		"Rain Chance":            "Para kanman",
+
+		// Charts and their spoken summaries
+		"Temperature Trend (24 hours)":                          "Q'uñi purisqan (24 ura)",
+		"Precipitation Chance (24 hours)":                       "Para kanman (24 ura)",
+		"Temperature %s from %.0f°C at %s":                      "Q'uñiqa %[3]s uramanta %.0[2]f°C %[1]s",
+		"Temperature holds steady around %.0f°C from %s to %s.": "Q'uñiqa %[2]s uramanta %[3]s urakama %.0[1]f°C hinalla kashan.",
+		"rises":                     "wicharin",
+		"falls":                     "uraykun",
+		"holds":                     "hinalla kan",
+		"then %s":                   "chaymanta %s",
+		"to %.0f°C at %s":           "%[2]s urapi %.0[1]f°C kama",
+		"to a peak of %.0f°C at %s": "%[2]s urapi aswan %.0[1]f°C kama",
+		"to a low of %.0f°C at %s":  "%[2]s urapi aslla %.0[1]f°C kama",
+		"No rain expected.":         "Mana para kanqachu.",
+		"at %s":                     "%s urapi",
+		"from %s to %s":             "%s uramanta %s urakama",
+		"and":                       "hinallataq",
+		"Chance of rain stays below %d%%, peaking at %d%% at %s.":       "Para kanmanqa %d%% urallanpi kashan, aswanqa %d%% %s urapi.",
+		"Chance of rain peaks at %d%% at %s, and is %d%% or higher %s.": "Para kanmanqa %d%% %s urapi aswan, %[4]s %[3]d%% nisqamanta aswan.",
+
+		// Placeholders
+		"No forecast data available":                                                       "Mana willakuy kanchu",
+		"No hourly data available":                                                         "Mana ura ura willay kanchu",
+		"No hourly temperatures available":                                                 "Mana ura ura q'uñi kanchu",
+		"No hourly precipitation data available":                                           "Mana ura ura para willay kanchu",
+		"No history available for this date":                                               "Kay p'unchaypaq mana ñawpaq willay kanchu",
+		"Nothing recorded yet; run 'illapaca log' to start recording":                      "Manaraq imapas qillqasqachu; 'illapaca log' nisqawan qallariy",
+		"Not enough recorded days yet; run 'illapaca climate fetch' or 'illapaca log'":     "Manaraq aypanchu qillqasqa p'unchaykuna; 'illapaca climate fetch' utaq 'illapaca log' nisqata ruway",
+		"Not enough recorded forecasts and observations yet; run 'illapaca log' regularly": "Manaraq aypanchu willakuykuna qhawasqakunapas; sapa kuti 'illapaca log' nisqata ruway",
+
+		// Alerts
+		"High temperature (%.1f°C) exceeds threshold (%.1f°C)":        "Ancha q'uñi (%.1f°C) tuputa atipan (%.1f°C)",
+		"Low temperature (%.1f°C) below threshold (%.1f°C)":           "Ancha chiri (%.1f°C) tupumanta uraypi (%.1f°C)",
+		"High wind speed (%.1f km/h) exceeds threshold (%.1f km/h)":   "Sinchi wayra (%.1f km/h) tuputa atipan (%.1f km/h)",
+		"High chance of rain (%d%%) on %s exceeds threshold (%.0f%%)": "%[2]s p'unchaypi para kanman (%[1]d%%) tuputa atipan (%.0[3]f%%)",
+		"Alert Threshold Settings:":                                   "Yuyachiy tupukuna:",
+		"High Temperature: %.1f°C":                                    "Ancha q'uñi: %.1f°C",
+		"Low Temperature: %.1f°C":                                     "Ancha chiri: %.1f°C",
+		"Precipitation Chance: %.0f%%":                                "Para kanman: %.0f%%",
+		"Wind Speed: %.1f km/h":                                       "Wayra utqay: %.1f km/h",
+
+		// Dashboards
+		"ILLAPA WEATHER DASHBOARD":                   "ILLAPA PACHA QHAWANA",
+		"ILLAPA WEATHER":                             "ILLAPA PACHA",
+		"(Feels: %.1f°C)":                            "(Musyakun: %.1f°C)",
+		"Wind: %.1f km/h %s":                         "Wayra: %.1f km/h %s",
+		"Hum: %d%%":                                  "Huq'u: %d%%",
+		"3-Day Forecast:":                            "Kimsa p'unchay willakuy:",
+		"%d weather alerts detected":                 "%d pacha yuyachiykuna",
+		"%s: %s %.1f°C/%.1f°C | Rain: %d%%":          "%s: %s %.1f°C/%.1f°C | Para: %d%%",
+		"%s: %s, high %.1f°C, low %.1f°C, rain %d%%": "%s: %s, aswan %.1f°C, aslla %.1f°C, para %d%%",
+
+		// Comparison
+		"Location Comparison: ":       "Llaqtakunata tupachiy: ",
+		"vs":                          "-",
+		"Metric":                      "Tupu",
+		"Difference":                  "Chhikan",
+		"Temperature":                 "Q'uñi",
+		"Feels Like":                  "Musyakun",
+		"Humidity":                    "Huq'u",
+		"Wind Speed":                  "Wayra utqay",
+		"Wind Direction":              "Wayra maymanta",
+		"Precipitation":               "Para",
+		"Visibility":                  "Rikuy",
+		"Local Time":                  "Kaypi ura",
+		"%s is %.1f°C warmer than %s": "%[1]s %[3]s-manta %.1[2]f°C aswan q'uñi",
+		"%s is %.1f°C colder than %s": "%[1]s %[3]s-manta %.1[2]f°C aswan chiri",
+		"%s is more humid than %s":    "%s %s-manta aswan huq'u",
+		"%s is drier than %s":         "%s %s-manta aswan ch'aki",
+		"%s is windier than %s":       "%s %s-manta aswan wayrayuq",
+		"%s is calmer than %s":        "%s %s-manta aswan thak",
+
+		// History, climate and accuracy
+		"Weather History: %s, %s":   "Ñawpaq pacha: %s, %s",
+		"Recorded History: %s":      "Qillqasqa ñawpaq pacha: %s",
+		"Avg":                       "Chawpi",
+		"Precip":                    "Para",
+		"Max Wind":                  "Aswan wayra",
+		"Readings":                  "Qhawasqakuna",
+		"Yes":                       "Arí",
+		"No":                        "Manan",
+		"Climate for %s on %s":      "%s llaqtapa pachan, %s",
+		"Normal:":                   "Sapa kuti:",
+		"Normal high:":              "Sapa kuti aswan:",
+		"Normal low:":               "Sapa kuti aslla:",
+		"Typical range:":            "Sapa kuti kaynin:",
+		"Record high:":              "Aswan q'uñi:",
+		"Record low:":               "Aswan chiri:",
+		"%.1f°C on %s":              "%.1f°C, %s",
+		"Based on %d recorded days": "%d qillqasqa p'unchaykunamanta",
+		"%.1f°C to %.1f°C (10th to 90th percentile, median %.1f°C)": "%.1f°C manta %.1f°C kama (10 manta 90 percentil kama, chawpi %.1f°C)",
+		"Forecast Accuracy: %s": "Willakuypa chiqaq kaynin: %s",
+		"Provider":              "Quq",
+		"Lead":                  "Ñawpaqta",
+		"Samples":               "Qhawasqakuna",
+		"Max MAE":               "Aswan MAE",
+		"Max Bias":              "Aswan Bias",
+		"Min MAE":               "Aslla MAE",
+		"Min Bias":              "Aslla Bias",
+		"Rain MAE":              "Para MAE",
+		"Rain Bias":             "Para Bias",
+		"Same day":              "Kikin p'unchay",
+		"1 day":                 "1 p'unchay",
+		"%d days":               "%d p'unchay",
+		"%s has the lowest temperature error here (%.1f°C on average)": "%s kaypi aswan pisi q'uñi pantayniyuq (chawpipi %.1f°C)",
+
+		// Wind
+		"Wind: %s, %s":                "Wayra: %s, %s",
+		"%.1f km/h from the %s (%d°)": "%.1f km/h %s ladumanta (%d°)",
+		"Gusts up to %.1f km/h":       "Sinchi wayra %.1f km/h kama",
+		"Beaufort %d: %s":             "Beaufort %d: %s",
+		"Calm":                        "Mana wayrayuq",
+		"Light air":                   "Pisillan wayra",
+		"Light breeze":                "Pisi wayra",
+		"Gentle breeze":               "Llamp'u wayra",
+		"Moderate breeze":             "Chawpi wayra",
+		"Fresh breeze":                "Chiri wayra",
+		"Strong breeze":               "Sinchi wayra",
+		"Near gale":                   "Ancha sinchi wayra",
+		"Gale":                        "Manchay wayra",
+		"Strong gale":                 "Ancha manchay wayra",
+		"Storm":                       "Saqra wayra",
+		"Violent storm":               "Ancha saqra wayra",
+		"Hurricane force":             "Muyu wayra",
+		"Sustained Wind and Gusts (next 24 hours)": "Wayra, sinchi wayrapas (hamuq 24 ura)",
+		"No hourly wind data available":            "Manam uraman wayra willakuy kanchu",
+		"%.0f / %.0f km/h":                         "%.0f / %.0f km/h",
+		"sustained":                                "wayra",
+		"gusts":                                    "sinchi wayra",
+		"Sustained wind peaks at %.0f km/h at %s":  "Wayraqa %.0f km/h kama chayan %s urapi",
+		"with gusts up to %.0f km/h at %s":         "sinchi wayrataq %.0f km/h kama %s urapi",
+		"Wind Rose (%s)":                           "Wayra t'ika (%s)",
+		"Calm all day.":                            "Tukuy p'unchay mana wayrayuq.",
+		"Wind by direction: %s.":                   "Wayra ladunkama: %s.",
+		"Mostly from the %s (%d%% of hours)":       "Aswantaqa %s ladumanta (%d%% urakuna)",
+		"Calm for %d hours":                        "%d ura mana wayrayuq",
+
+		// Heatmaps
+		"Temperature by Hour":                   "Q'uñi urakunapi",
+		"Chance of Rain by Hour":                "Para kanman urakunapi",
+		"Wind by Hour":                          "Wayra urakunapi",
+		"UV Index by Hour":                      "UV tupu urakunapi",
+		"UV not available from %s":              "%s mana UV qunchu",
+		"No hourly forecast data available":     "Manam urakunapaq willakuy kanchu",
+		"%s: lowest %s at %s, highest %s at %s": "%s: aswan pisi %s %s urapi, aswan hatun %s %s urapi",
+		"Warmest dry hour: %s at %s, %.0f°C":    "Aswan q'uñi ch'aki ura: %s, %s urapi, %.0f°C",
+
+		// Trends and favorites
+		"Next 24h:":                              "Hamuq 24 ura:",
+		"Next 24 hours: temperature %s, rain %s": "Hamuq 24 ura: q'uñi %s, para %s",
+		"Temp (24h)":                             "Q'uñi 24 ura",
+		"Rain (24h)":                             "Para 24 ura",
+		"%.0f°C to %.0f°C":                       "%.0f°C manta %.0f°C kama",
+		"up to %.0f%%":                           "%.0f%% kama",
+		"Favorite Locations":                     "Munasqa llaqtakuna",
+		"No favorite locations saved":            "Manam munasqa llaqtakuna kanchu",
+		"Location":                               "Llaqta",
+		"Now":                                    "Kunan",
+		"High/Low":                               "Aswan/Aslla",
+
+		// Activity planning
+		"Running":                   "Phawakay",
+		"Cycling":                   "Bicicletapi puriy",
+		"Hiking":                    "Purikachay",
+		"Photography":               "Fotografiay",
+		"Laundry":                   "P'acha t'aqsay",
+		"Barbecue":                  "Kankacha",
+		"Best Times for %s: %s, %s": "%s ruwanapaq aswan allin pachakuna: %s, %s",
+		"Suggested Windows":         "Yuyaychasqa pachakuna",
+		"Hourly Scores":             "Sapa horapi chaninchay",
+		"No hours score %d or more; the best is %s at %s, scoring %d": "Mana ima horapas %d-man chayanchu; aswan allinqa %s %s-pi, %d-wan",
+		"rain up to %d%%":                        "para %d%% kama",
+		"dry":                                    "ch'aki",
+		"wind up to %.0f km/h":                   "wayra %.0f km/h kama",
+		"%d. %s, %s to %s: score %d of 100, %s.": "%d. %s, %s-manta %s-kama: chaninchay %d 100-manta, %s.",
+		"%s: best %d at %s, worst %d at %s":      "%s: aswan allin %d %s-pi, aswan millay %d %s-pi",
+
+		// Comfort and advice
+		"Comfort and Advice": "Allin kawsay, yuyaychaykuna",
+		"Dew point:":         "Sulla pacha:",
+		"Heat index:":        "Rupay tupu:",
+		"Wind chill:":        "Wayra chiri:",
+		"Humidex:":           "Humidex:",
+		"comfortable":        "allin",
+		"slightly humid":     "pisilla hump'i",
+		"humid":              "hump'i",
+		"muggy":              "ancha hump'i",
+		"oppressive":         "sinchi hump'i",
+		"Layers:":            "P'achakuna:",
+		"Umbrella:":          "Para p'acha:",
+		"Sunscreen:":         "Inti hark'ana:",
+		"Hydration:":         "Yaku ukyay:",
+		"Heavy winter coat, hat, gloves and scarf":                    "Rakhu chiri p'acha, ch'ullu, makikuna, kunka p'acha",
+		"Winter coat, hat and gloves":                                 "Chiri p'acha, ch'ullu, makikuna",
+		"Warm jacket over a sweater":                                  "Q'oñi casaca chompa hawanpi",
+		"Light jacket or sweater":                                     "Llamp'u casaca utaq chompa",
+		"Long sleeves or a light layer":                               "Suni makiyuq utaq llamp'u p'acha",
+		"T-shirt weather":                                             "Polo p'acha pacha",
+		"Light, breathable clothing":                                  "Llamp'u, samaq p'achakuna",
+		"add a windproof layer":                                       "wayra hark'aq p'achata yapay",
+		"Take one, it is raining":                                     "Apakuy, paramushanmi",
+		"Take one, %d%% chance of rain in the coming hours":           "Apakuy, %d%% para hamunanpaq qatiq horakunapi",
+		"Worth packing, %d%% chance of rain in the coming hours":      "Apakuyqa allin, %d%% para hamunanpaq qatiq horakunapi",
+		"Not needed":                                                  "Mana necesitakunchu",
+		"SPF 50+, hat and sunglasses; avoid the midday sun (UV %.0f)": "SPF 50+, chuku, inti qhawana; chawpi p'unchaw intita ama (UV %.0f)",
+		"SPF 30+, hat and sunglasses (UV %.0f)":                       "SPF 30+, chuku, inti qhawana (UV %.0f)",
+		"SPF 30 if you are out for long (UV %.0f)":                    "SPF 30 unay hawapi kaspaqa (UV %.0f)",
+		"Not needed (UV %.0f)":                                        "Mana necesitakunchu (UV %.0f)",
+		"UV index not available from %s":                              "%s mana UV indiceta qunchu",
+		"Drink water often and rest in the shade (feels like %.0f°C)": "Sapa kuti yakuta ukyay, llanthupi samay (%.0f°C hina)",
+		"Drink more water than usual (feels like %.0f°C)":             "Aswan yakuta ukyay (%.0f°C hina)",
+		"Carry a water bottle":                                        "Yaku botellata apakuy",
+		"Drink as usual":                                              "Sapa kuti hina ukyay",
+
+		// Commute
+		"Commute: %s to %s": "Puriy: %s-manta %s-kama",
+		"Leaving at %s, returning at %s, %.0f minutes each way": "%s-pi lloqsiy, %s-pi kutimuy, %.0f minutos sapa puriypi",
+		"No upcoming workday trips in the forecast":             "Mana llamk'ay p'unchaw puriykuna willaypichu kan",
+		"Day":                                "P'unchaw",
+		"Trip":                               "Puriy",
+		"Leave":                              "Lloqsiy",
+		"Arrive":                             "Chayay",
+		"Watch Out":                          "Qhaway",
+		"Outbound":                           "Riy",
+		"Return":                             "Kutimuy",
+		"Mon":                                "Lun",
+		"Tue":                                "Mar",
+		"Wed":                                "Miér",
+		"Thu":                                "Juev",
+		"Fri":                                "Vier",
+		"%s %s, %.0f°C, rain %d%%":           "%s %s, %.0f°C, para %d%%",
+		"Rain %d%%":                          "Para %d%%",
+		"Wind %.0f km/h":                     "Wayra %.0f km/h",
+		"The trip looks dry and calm":        "Puriyqa ch'aki, thak kanqa",
+		"All %d trips look dry and calm":     "%d puriykunaqa ch'aki, thak kanqa",
+		"%d of %d trips may be wet or windy": "%d puriykuna %d-manta paraq utaq wayraq kanman",
+		"%s %s, feels like %s":               "%s %s, musyakun %s",
+		"Humidity %d%%, wind %s, rain %d%%":  "Huq'u %d%%, wayra %s, para %d%%",
+	},
+}
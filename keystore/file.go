@@ -0,0 +1,208 @@
+package keystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"filippo.io/age"
+	"golang.org/x/term"
+)
+
+// PassphraseEnv can hold the passphrase for non-interactive use
+const PassphraseEnv = "ILLAPACA_PASSPHRASE"
+
+// fileStore keeps keys in an age-encrypted JSON file protected by a passphrase
+type fileStore struct {
+	path       string
+	passphrase string
+}
+
+// fileStores are the stores opened by this process by path, so that a
+// command reading several keys asks for the passphrase once
+var (
+	fileStoresMu sync.Mutex
+	fileStores   = map[string]*fileStore{}
+)
+
+func newFileStore() (*fileStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(home, ".illapaca-credentials.age")
+
+	fileStoresMu.Lock()
+	defer fileStoresMu.Unlock()
+	if s, ok := fileStores[path]; ok {
+		return s, nil
+	}
+	s := &fileStore{path: path}
+	fileStores[path] = s
+	return s, nil
+}
+
+func (s *fileStore) Name() string {
+	return "encrypted file " + s.path
+}
+
+func (s *fileStore) exists() bool {
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+func (s *fileStore) Get(provider string) (string, error) {
+	keys, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	key, ok := keys[provider]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return key, nil
+}
+
+func (s *fileStore) Set(provider, key string) error {
+	keys, err := s.load()
+	if err != nil {
+		return err
+	}
+	keys[provider] = key
+	return s.save(keys)
+}
+
+func (s *fileStore) Delete(provider string) error {
+	keys, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := keys[provider]; !ok {
+		return ErrNotFound
+	}
+	delete(keys, provider)
+
+	if len(keys) == 0 {
+		return os.Remove(s.path)
+	}
+	return s.save(keys)
+}
+
+// load decrypts the key file; a missing file is an empty store
+func (s *fileStore) load() (map[string]string, error) {
+	keys := map[string]string{}
+
+	ciphertext, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase, err := s.readPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), identity)
+	if err != nil {
+		// Ask again next time rather than reusing a mistyped passphrase
+		s.passphrase = ""
+		return nil, fmt.Errorf("decrypting %s: %w", s.path, err)
+	}
+
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(plaintext, &keys); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", s.path, err)
+	}
+	return keys, nil
+}
+
+// save encrypts keys and writes them with owner-only permissions
+func (s *fileStore) save(keys map[string]string) error {
+	passphrase, err := s.readPassphrase(!s.exists())
+	if err != nil {
+		return err
+	}
+
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipient)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, buf.Bytes(), 0600)
+}
+
+// readPassphrase returns the cached, environment or prompted passphrase.
+// When confirm is set the user is asked to type a new passphrase twice.
+func (s *fileStore) readPassphrase(confirm bool) (string, error) {
+	if s.passphrase != "" {
+		return s.passphrase, nil
+	}
+	if env := os.Getenv(PassphraseEnv); env != "" {
+		s.passphrase = env
+		return env, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("passphrase required for %s; set %s", s.path, PassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "Passphrase for API key file: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(again) != string(passphrase) {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	if len(passphrase) == 0 {
+		return "", fmt.Errorf("passphrase must not be empty")
+	}
+
+	s.passphrase = string(passphrase)
+	return s.passphrase, nil
+}
@@ -0,0 +1,44 @@
+package keystore
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the service name entries are stored under
+const keyringService = "illapaca"
+
+// keyringStore keeps keys in the system keyring (Secret Service on Linux,
+// Keychain on macOS, Credential Manager on Windows)
+type keyringStore struct{}
+
+func (keyringStore) Name() string {
+	return "system keyring"
+}
+
+func (keyringStore) Get(provider string) (string, error) {
+	key, err := keyring.Get(keyringService, provider)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return key, err
+}
+
+func (keyringStore) Set(provider, key string) error {
+	return keyring.Set(keyringService, provider, key)
+}
+
+func (keyringStore) Delete(provider string) error {
+	err := keyring.Delete(keyringService, provider)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// keyringAvailable probes the keyring; a missing entry means it works
+func keyringAvailable() bool {
+	_, err := keyring.Get(keyringService, "probe")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
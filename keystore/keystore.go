@@ -0,0 +1,71 @@
+// Package keystore stores provider API keys outside the config file
+package keystore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when no key is stored for a provider
+var ErrNotFound = errors.New("no API key stored")
+
+// Store keeps one API key per weather provider
+type Store interface {
+	// Name describes the backend for status output
+	Name() string
+	Get(provider string) (string, error)
+	Set(provider, key string) error
+	Delete(provider string) error
+}
+
+// Backend names accepted by Open
+const (
+	BackendAuto    = "auto"
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+)
+
+// Open returns the requested store. With BackendAuto the system keyring is
+// used when a Secret Service is reachable, otherwise the encrypted file.
+func Open(backend string) (Store, error) {
+	switch backend {
+	case BackendKeyring:
+		if !keyringAvailable() {
+			return nil, fmt.Errorf("system keyring is not available")
+		}
+		return keyringStore{}, nil
+	case BackendFile:
+		return newFileStore()
+	case BackendAuto, "":
+		if keyringAvailable() {
+			return keyringStore{}, nil
+		}
+		return newFileStore()
+	default:
+		return nil, fmt.Errorf("unknown key store %q (use auto, keyring or file)", backend)
+	}
+}
+
+// Lookup searches every available backend for a provider's key
+func Lookup(provider string) (string, Store, error) {
+	if keyringAvailable() {
+		store := keyringStore{}
+		if key, err := store.Get(provider); err == nil {
+			return key, store, nil
+		}
+	}
+
+	store, err := newFileStore()
+	if err != nil {
+		return "", nil, err
+	}
+	if !store.exists() {
+		return "", nil, ErrNotFound
+	}
+
+	key, err := store.Get(provider)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, store, nil
+}
@@ -0,0 +1,37 @@
+package keystore
+
+import (
+	"os"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestLookupReusesFileStore(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(PassphraseEnv, "correct horse")
+
+	store, err := Open(BackendFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for provider, key := range map[string]string{"weatherapi": "wa-key", "openweathermap": "owm-key"} {
+		if err := store.Set(provider, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Without the variable and a terminal, a fresh store could not ask for
+	// the passphrase, so every lookup must reuse the one already unlocked
+	os.Unsetenv(PassphraseEnv)
+	for provider, want := range map[string]string{"weatherapi": "wa-key", "openweathermap": "owm-key"} {
+		key, _, err := Lookup(provider)
+		if err != nil {
+			t.Fatalf("Lookup(%s): %v", provider, err)
+		}
+		if key != want {
+			t.Errorf("Lookup(%s) = %q, want %q", provider, key, want)
+		}
+	}
+}
@@ -1,11 +1,14 @@
 // model/weather.go
 package model
 
+import "time"
+
 // Original models - we'll keep these as our unified internal format
 type WeatherData struct {
 	Current  CurrentWeather `json:"current"`
 	Location Location       `json:"location"`
 	Forecast Forecast       `json:"forecast"`
+	Provider string         `json:"provider,omitempty"`
 }
 
 type CurrentWeather struct {
@@ -15,7 +18,9 @@ type CurrentWeather struct {
 	Condition  Condition `json:"condition"`
 	WindMph    float64   `json:"wind_mph"`
 	WindKph    float64   `json:"wind_kph"`
+	WindDegree int       `json:"wind_degree"`
 	WindDir    string    `json:"wind_dir"`
+	GustKph    float64   `json:"gust_kph"`
 	PressureMb float64   `json:"pressure_mb"`
 	PrecipMm   float64   `json:"precip_mm"`
 	Humidity   int       `json:"humidity"`
@@ -73,8 +78,22 @@ type Hour struct {
 	TimeEpoch    int64     `json:"time_epoch"`
 	Time         string    `json:"time"`
 	TempC        float64   `json:"temp_c"`
+	FeelsLikeC   float64   `json:"feelslike_c"`
+	IsDay        int       `json:"is_day"`
 	Condition    Condition `json:"condition"`
 	ChanceOfRain int       `json:"chance_of_rain"`
+	WindKph      float64   `json:"wind_kph"`
+	WindDegree   int       `json:"wind_degree"`
+	GustKph      float64   `json:"gust_kph"`
+	UV           float64   `json:"uv"`
+}
+
+// AlertThresholds for weather alerts
+type AlertThresholds struct {
+	HighTemp      float64 `json:"high_temp"`
+	LowTemp       float64 `json:"low_temp"`
+	Precipitation float64 `json:"precipitation"`
+	WindSpeed     float64 `json:"wind_speed"`
 }
 
 // HistoricalData for comparison
@@ -83,6 +102,93 @@ type HistoricalData struct {
 	Forecast Forecast `json:"forecast"`
 }
 
+// Observation is the current conditions recorded at one location at one
+// time, as kept in the local history database
+type Observation struct {
+	Location   string         `json:"location"`
+	Provider   string         `json:"provider"`
+	ObservedAt time.Time      `json:"observed_at"`
+	Place      Location       `json:"place"`
+	Current    CurrentWeather `json:"current"`
+}
+
+// ForecastRecord is one forecast day as issued at a given time.
+// IssuedDate is the local date at the location when it was issued.
+type ForecastRecord struct {
+	Location   string      `json:"location"`
+	Provider   string      `json:"provider"`
+	IssuedAt   time.Time   `json:"issued_at"`
+	IssuedDate string      `json:"issued_date"`
+	Day        ForecastDay `json:"day"`
+}
+
+// ObservedDay summarizes the weather observed on one local date, either
+// from recorded observations or from a provider's history
+type ObservedDay struct {
+	Date       string  `json:"date"`
+	MinTempC   float64 `json:"min_temp_c"`
+	MaxTempC   float64 `json:"max_temp_c"`
+	AvgTempC   float64 `json:"avg_temp_c"`
+	MaxWindKph float64 `json:"max_wind_kph"`
+	Rained     bool    `json:"rained"`
+	Readings   int     `json:"readings"`
+}
+
+// ErrorStats are the mean absolute error and mean signed error (bias) of
+// forecast values against observations
+type ErrorStats struct {
+	MAE  float64
+	Bias float64
+}
+
+// ForecastAccuracy scores one provider's forecasts made LeadDays ahead.
+// Rain errors are in percentage points against 100 for a day with rain
+// and 0 for a dry day.
+type ForecastAccuracy struct {
+	Provider string
+	LeadDays int
+	Samples  int
+	MaxTemp  ErrorStats
+	MinTemp  ErrorStats
+	Rain     ErrorStats
+}
+
+// ClimateDay holds statistics for one calendar day (MM-DD). Normals and
+// percentiles use recorded days within a window around it in every year;
+// records use the exact calendar day only and are empty without one.
+type ClimateDay struct {
+	MonthDay       string
+	Samples        int
+	NormalTempC    float64
+	NormalMaxC     float64
+	NormalMinC     float64
+	P10TempC       float64
+	P50TempC       float64
+	P90TempC       float64
+	RecordHighC    float64
+	RecordHighDate string
+	RecordLowC     float64
+	RecordLowDate  string
+}
+
+// Climate is the per calendar day statistics of one location
+type Climate struct {
+	Location string
+	Days     map[string]ClimateDay
+}
+
+// Day returns the statistics for date, given as YYYY-MM-DD or MM-DD
+func (c *Climate) Day(date string) (ClimateDay, bool) {
+	if c == nil {
+		return ClimateDay{}, false
+	}
+	if len(date) == len("2006-01-02") {
+		date = date[5:]
+	}
+	day, ok := c.Days[date]
+	return day, ok
+}
+
 // OpenWeatherMap specific models
 type Coordinates struct {
 	Lat     float64 `json:"lat"`
@@ -118,6 +224,7 @@ type OpenWeatherCurrent struct {
 	Wind       struct {
 		Speed float64 `json:"speed"`
 		Deg   float64 `json:"deg"`
+		Gust  float64 `json:"gust"`
 	} `json:"wind"`
 	Rain struct {
 		OneHour float64 `json:"1h"`
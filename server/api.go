@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
+	"github.com/biferdou/illapaca/ui"
+	"golang.org/x/sync/singleflight"
+)
+
+// MaxForecastDays limits the days a client may request
+const MaxForecastDays = 14
+
+// APIOptions configures the JSON API
+type APIOptions struct {
+	// Token, when set, must be sent as "Authorization: Bearer <token>"
+	Token string
+
+	// AlertThresholds decide which conditions /v1/alerts reports
+	AlertThresholds model.AlertThresholds
+}
+
+// CompareResponse is returned by /v1/compare. Differences are the first
+// location minus the second.
+type CompareResponse struct {
+	Locations  []*model.WeatherData `json:"locations"`
+	Difference struct {
+		TempC      float64 `json:"temp_c"`
+		FeelsLikeC float64 `json:"feelslike_c"`
+		Humidity   int     `json:"humidity"`
+		WindKph    float64 `json:"wind_kph"`
+	} `json:"difference"`
+}
+
+// AlertsResponse is returned by /v1/alerts
+type AlertsResponse struct {
+	Location   model.Location        `json:"location"`
+	Thresholds model.AlertThresholds `json:"thresholds"`
+	Alerts     []string              `json:"alerts"`
+}
+
+// errorResponse is the body of every non-200 response
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// apiServer serves weather from one client to many callers, merging identical
+// requests that arrive while one is in flight
+type apiServer struct {
+	client *illapaca.Client
+	opts   APIOptions
+	group  singleflight.Group
+}
+
+// APIHandler serves the JSON API:
+//
+//	GET /v1/current?q=Cusco
+//	GET /v1/forecast?q=Cusco&days=3
+//	GET /v1/compare?q=Cusco&q=Lima
+//	GET /v1/alerts?q=Cusco
+//
+// The client should cache responses; concurrent identical queries are
+// coalesced into a single fetch.
+func APIHandler(client *illapaca.Client, opts APIOptions) http.Handler {
+	a := &apiServer{client: client, opts: opts}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/current", a.current)
+	mux.HandleFunc("GET /v1/forecast", a.forecast)
+	mux.HandleFunc("GET /v1/compare", a.compare)
+	mux.HandleFunc("GET /v1/alerts", a.alerts)
+	return a.authorize(mux)
+}
+
+// authorize rejects requests without the bearer token, if one is set
+func (a *apiServer) authorize(next http.Handler) http.Handler {
+	if a.opts.Token == "" {
+		return next
+	}
+	want := []byte("Bearer " + a.opts.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="illapaca"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// fetch returns weather for location, sharing the result with identical
+// requests already in flight
+func (a *apiServer) fetch(ctx context.Context, location string, days int) (*model.WeatherData, error) {
+	key := fmt.Sprintf("%s|%d", strings.ToLower(strings.TrimSpace(location)), days)
+	v, err, _ := a.group.Do(key, func() (interface{}, error) {
+		// Detach from the first caller so its cancellation does not fail
+		// the others waiting on this fetch
+		return a.client.FetchWeather(context.WithoutCancel(ctx), location, days)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*model.WeatherData), nil
+}
+
+func (a *apiServer) current(w http.ResponseWriter, r *http.Request) {
+	location, ok := requireLocation(w, r)
+	if !ok {
+		return
+	}
+	data, err := a.fetch(r.Context(), location, 1)
+	if err != nil {
+		writeError(w, fetchStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, data)
+}
+
+func (a *apiServer) forecast(w http.ResponseWriter, r *http.Request) {
+	location, ok := requireLocation(w, r)
+	if !ok {
+		return
+	}
+
+	days := 3
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > MaxForecastDays {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", MaxForecastDays))
+			return
+		}
+		days = n
+	}
+
+	data, err := a.fetch(r.Context(), location, days)
+	if err != nil {
+		writeError(w, fetchStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, data)
+}
+
+func (a *apiServer) compare(w http.ResponseWriter, r *http.Request) {
+	locations := r.URL.Query()["q"]
+	if len(locations) != 2 {
+		writeError(w, http.StatusBadRequest, "compare needs exactly two q parameters")
+		return
+	}
+
+	var response CompareResponse
+	for _, location := range locations {
+		data, err := a.fetch(r.Context(), location, 1)
+		if err != nil {
+			writeError(w, fetchStatus(err), err.Error())
+			return
+		}
+		response.Locations = append(response.Locations, data)
+	}
+
+	first, second := response.Locations[0].Current, response.Locations[1].Current
+	response.Difference.TempC = first.TempC - second.TempC
+	response.Difference.FeelsLikeC = first.FeelsLikeC - second.FeelsLikeC
+	response.Difference.Humidity = first.Humidity - second.Humidity
+	response.Difference.WindKph = first.WindKph - second.WindKph
+	writeJSON(w, response)
+}
+
+func (a *apiServer) alerts(w http.ResponseWriter, r *http.Request) {
+	location, ok := requireLocation(w, r)
+	if !ok {
+		return
+	}
+	data, err := a.fetch(r.Context(), location, 3)
+	if err != nil {
+		writeError(w, fetchStatus(err), err.Error())
+		return
+	}
+
+	alerts := ui.Alerts(data, a.opts.AlertThresholds)
+	if alerts == nil {
+		alerts = []string{}
+	}
+	writeJSON(w, AlertsResponse{
+		Location:   data.Location,
+		Thresholds: a.opts.AlertThresholds,
+		Alerts:     alerts,
+	})
+}
+
+// fetchStatus picks the response status for a failed fetch. Locations the
+// provider does not know are 404 and other requests it refuses are 400;
+// a rejected key, rate limiting, provider errors and network failures
+// are the server's problem and stay 502.
+func fetchStatus(err error) int {
+	if errors.Is(err, api.ErrLocationNotFound) {
+		return http.StatusNotFound
+	}
+	var status *api.StatusError
+	if !errors.As(err, &status) {
+		return http.StatusBadGateway
+	}
+	switch status.StatusCode {
+	case http.StatusNotFound:
+		return http.StatusNotFound
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return http.StatusBadGateway
+	}
+	if status.StatusCode >= 400 && status.StatusCode < 500 {
+		return http.StatusBadRequest
+	}
+	return http.StatusBadGateway
+}
+
+// requireLocation returns the q parameter, answering 400 if it is missing
+func requireLocation(w http.ResponseWriter, r *http.Request) (string, bool) {
+	location := strings.TrimSpace(r.URL.Query().Get("q"))
+	if location == "" {
+		writeError(w, http.StatusBadRequest, "missing q parameter")
+		return "", false
+	}
+	return location, true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
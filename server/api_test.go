@@ -0,0 +1,171 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/model"
+)
+
+func get(t *testing.T, h http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("GET", target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPIEndpoints(t *testing.T) {
+	thresholds := model.AlertThresholds{HighTemp: 35, LowTemp: 0, Precipitation: 70, WindSpeed: 30}
+	h := APIHandler(newTestClient(t), APIOptions{AlertThresholds: thresholds})
+
+	var current model.WeatherData
+	decode(t, get(t, h, "/v1/current?q=London", nil), &current)
+	if current.Location.Name != "London" || current.Current.TempC != 16.2 {
+		t.Errorf("current = %s %.1f", current.Location.Name, current.Current.TempC)
+	}
+
+	var forecast model.WeatherData
+	decode(t, get(t, h, "/v1/forecast?q=London&days=2", nil), &forecast)
+	if n := len(forecast.Forecast.ForecastDay); n != 2 {
+		t.Errorf("forecast days = %d, want 2", n)
+	}
+
+	var compare CompareResponse
+	decode(t, get(t, h, "/v1/compare?q=London&q=Empty", nil), &compare)
+	if len(compare.Locations) != 2 {
+		t.Fatalf("compare locations = %d", len(compare.Locations))
+	}
+	want := compare.Locations[0].Current.TempC - compare.Locations[1].Current.TempC
+	if compare.Difference.TempC != want {
+		t.Errorf("temperature difference = %.1f, want %.1f", compare.Difference.TempC, want)
+	}
+
+	var alerts AlertsResponse
+	decode(t, get(t, h, "/v1/alerts?q=London", nil), &alerts)
+	if len(alerts.Alerts) != 1 {
+		t.Errorf("alerts = %v, want 1", alerts.Alerts)
+	}
+
+	for target, status := range map[string]int{
+		"/v1/current":                  http.StatusBadRequest,
+		"/v1/forecast?q=London&days=0": http.StatusBadRequest,
+		"/v1/forecast?q=London&days=x": http.StatusBadRequest,
+		"/v1/compare?q=London":         http.StatusBadRequest,
+		"/v1/current?q=Atlantis":       http.StatusBadRequest,
+		"/v1/unknown":                  http.StatusNotFound,
+	} {
+		if rec := get(t, h, target, nil); rec.Code != status {
+			t.Errorf("%s: status = %d, want %d", target, rec.Code, status)
+		}
+	}
+}
+
+func TestAPIBearerToken(t *testing.T) {
+	h := APIHandler(newTestClient(t), APIOptions{Token: "s3cret"})
+
+	rec := get(t, h, "/v1/current?q=London", nil)
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("without token: status = %d", rec.Code)
+	}
+	rec = get(t, h, "/v1/current?q=London", http.Header{"Authorization": {"Bearer wrong"}})
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status = %d", rec.Code)
+	}
+	rec = get(t, h, "/v1/current?q=London", http.Header{"Authorization": {"Bearer s3cret"}})
+	if rec.Code != http.StatusOK {
+		t.Errorf("valid token: status = %d", rec.Code)
+	}
+}
+
+func TestAPICoalescesRequests(t *testing.T) {
+	// The provider holds every request until released, so concurrent
+	// callers must share the first fetch
+	var hits atomic.Int32
+	release := make(chan struct{})
+	upstream := apitest.Handler()
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		upstream.ServeHTTP(w, r)
+	}))
+	defer provider.Close()
+
+	client, err := illapaca.New(
+		illapaca.WithProvider(api.ProviderWeatherAPI, apitest.APIKey),
+		illapaca.WithBaseURL(provider.URL),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := APIHandler(client, APIOptions{})
+
+	var wg sync.WaitGroup
+	codes := make([]int, 8)
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = get(t, h, "/v1/current?q=London", nil).Code
+		}()
+	}
+
+	for hits.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := hits.Load(); n != 1 {
+		t.Errorf("provider requests = %d, want 1", n)
+	}
+	for i, code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("request %d: status = %d", i, code)
+		}
+	}
+}
+
+func TestFetchStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("%w: Atlantis", api.ErrLocationNotFound), http.StatusNotFound},
+		{&api.StatusError{StatusCode: http.StatusNotFound}, http.StatusNotFound},
+		{fmt.Errorf("fetching: %w", &api.StatusError{StatusCode: http.StatusBadRequest}), http.StatusBadRequest},
+		{&api.StatusError{StatusCode: http.StatusUnauthorized}, http.StatusBadGateway},
+		{&api.StatusError{StatusCode: http.StatusTooManyRequests}, http.StatusBadGateway},
+		{&api.StatusError{StatusCode: http.StatusServiceUnavailable}, http.StatusBadGateway},
+		{errors.New("request to provider failed: connection refused"), http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		if got := fetchStatus(tt.err); got != tt.want {
+			t.Errorf("fetchStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
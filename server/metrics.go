@@ -0,0 +1,126 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
+	"github.com/biferdou/illapaca/ui"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// locationLabels label every per-location metric
+var locationLabels = []string{"name", "country"}
+
+// gauge describes one weather reading exported as a gauge
+type gauge struct {
+	desc  *prometheus.Desc
+	value func(data *model.WeatherData) float64
+
+	// supplied reports whether a provider reports the reading; nil means
+	// every provider does
+	supplied func(provider string) bool
+}
+
+func newGauge(name, help string, value func(data *model.WeatherData) float64) gauge {
+	return gauge{
+		desc:  prometheus.NewDesc("illapaca_"+name, help, locationLabels, nil),
+		value: value,
+	}
+}
+
+// suppliedBy returns g exported only for data from providers that report it
+func (g gauge) suppliedBy(supplied func(provider string) bool) gauge {
+	g.supplied = supplied
+	return g
+}
+
+// metricsCollector reports the poller's latest snapshots at scrape time
+type metricsCollector struct {
+	poller     *Poller
+	thresholds model.AlertThresholds
+	gauges     []gauge
+	alerts     *prometheus.Desc
+	up         *prometheus.Desc
+	fetched    *prometheus.Desc
+}
+
+func newMetricsCollector(poller *Poller, thresholds model.AlertThresholds) *metricsCollector {
+	return &metricsCollector{
+		poller:     poller,
+		thresholds: thresholds,
+		gauges: []gauge{
+			newGauge("temperature_celsius", "Current temperature.",
+				func(d *model.WeatherData) float64 { return d.Current.TempC }),
+			newGauge("feels_like_celsius", "Current feels-like temperature.",
+				func(d *model.WeatherData) float64 { return d.Current.FeelsLikeC }),
+			newGauge("humidity_percent", "Current relative humidity.",
+				func(d *model.WeatherData) float64 { return float64(d.Current.Humidity) }),
+			newGauge("wind_speed_kph", "Current wind speed.",
+				func(d *model.WeatherData) float64 { return d.Current.WindKph }),
+			newGauge("pressure_hpa", "Current air pressure.",
+				func(d *model.WeatherData) float64 { return d.Current.PressureMb }),
+			newGauge("precipitation_mm", "Current precipitation.",
+				func(d *model.WeatherData) float64 { return d.Current.PrecipMm }),
+			newGauge("uv_index", "Current UV index.",
+				func(d *model.WeatherData) float64 { return d.Current.UV }).suppliedBy(api.SuppliesUV),
+		},
+		alerts: prometheus.NewDesc("illapaca_active_alerts",
+			"Number of alert thresholds currently exceeded.", locationLabels, nil),
+		up: prometheus.NewDesc("illapaca_up",
+			"Whether the last refresh of the location succeeded.", []string{"location"}, nil),
+		fetched: prometheus.NewDesc("illapaca_last_refresh_timestamp_seconds",
+			"When the location was last refreshed successfully.", locationLabels, nil),
+	}
+}
+
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, g := range c.gauges {
+		ch <- g.desc
+	}
+	ch <- c.alerts
+	ch <- c.up
+	ch <- c.fetched
+}
+
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	// Two queries can resolve to the same place; report it once
+	seen := map[[2]string]bool{}
+
+	for _, s := range c.poller.Snapshots() {
+		up := 1.0
+		if s.Err != nil {
+			up = 0
+		}
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, up, s.Location)
+
+		if s.Data == nil {
+			continue
+		}
+		place := [2]string{s.Data.Location.Name, s.Data.Location.Country}
+		if seen[place] {
+			continue
+		}
+		seen[place] = true
+
+		labels := place[:]
+		for _, g := range c.gauges {
+			if g.supplied != nil && !g.supplied(s.Data.Provider) {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, g.value(s.Data), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.alerts, prometheus.GaugeValue,
+			float64(len(ui.Alerts(s.Data, c.thresholds))), labels...)
+		ch <- prometheus.MustNewConstMetric(c.fetched, prometheus.GaugeValue,
+			float64(s.FetchedAt.Unix()), labels...)
+	}
+}
+
+// MetricsHandler serves the poller's data in the Prometheus text format
+func MetricsHandler(poller *Poller, thresholds model.AlertThresholds) http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newMetricsCollector(poller, thresholds))
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/model"
+)
+
+func newTestClient(t *testing.T) *illapaca.Client {
+	t.Helper()
+	provider := apitest.NewServer()
+	t.Cleanup(provider.Close)
+
+	client, err := illapaca.New(
+		illapaca.WithProvider(api.ProviderWeatherAPI, apitest.APIKey),
+		illapaca.WithBaseURL(provider.URL),
+		illapaca.WithCache(illapaca.NewMemoryCache(time.Minute)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestMetrics(t *testing.T) {
+	poller := NewPoller(newTestClient(t), []string{apitest.LocationOK, "london", "Atlantis"}, 3, time.Minute)
+	poller.Refresh(context.Background())
+
+	thresholds := model.AlertThresholds{HighTemp: 35, LowTemp: 0, Precipitation: 70, WindSpeed: 30}
+	rec := httptest.NewRecorder()
+	MetricsHandler(poller, thresholds).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	out := string(body)
+
+	for _, want := range []string{
+		`illapaca_temperature_celsius{country="United Kingdom",name="London"} 16.2`,
+		`illapaca_humidity_percent{country="United Kingdom",name="London"} 68`,
+		`illapaca_active_alerts{country="United Kingdom",name="London"} 1`,
+		`illapaca_up{location="London"} 1`,
+		`illapaca_up{location="Atlantis"} 0`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "illapaca_temperature_celsius{"); n != 1 {
+		t.Errorf("temperature reported %d times, want once per place", n)
+	}
+}
+
+func TestMetricsWithoutUV(t *testing.T) {
+	provider := apitest.NewServer()
+	defer provider.Close()
+	client, err := illapaca.New(
+		illapaca.WithProvider(api.ProviderOpenWeatherMap, apitest.APIKey),
+		illapaca.WithBaseURL(provider.URL),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poller := NewPoller(client, []string{apitest.LocationOK}, 3, time.Minute)
+	poller.Refresh(context.Background())
+
+	rec := httptest.NewRecorder()
+	MetricsHandler(poller, model.AlertThresholds{}).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	out := rec.Body.String()
+
+	if !strings.Contains(out, "illapaca_temperature_celsius{") {
+		t.Fatalf("metrics missing temperature:\n%s", out)
+	}
+	if strings.Contains(out, "illapaca_uv_index{") {
+		t.Errorf("UV index exported for a provider without one:\n%s", out)
+	}
+}
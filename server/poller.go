@@ -0,0 +1,95 @@
+// Package server runs Illapaca as a long-lived service that keeps the
+// weather for a set of locations fresh and publishes it over HTTP.
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/model"
+)
+
+// Snapshot is the latest weather fetched for one location
+type Snapshot struct {
+	Location  string
+	Data      *model.WeatherData
+	Err       error
+	FetchedAt time.Time
+}
+
+// Poller refreshes the weather for fixed locations on a schedule
+type Poller struct {
+	client    *illapaca.Client
+	locations []string
+	days      int
+	interval  time.Duration
+
+	mu        sync.RWMutex
+	snapshots map[string]Snapshot
+}
+
+// NewPoller creates a Poller that fetches days forecast days for each
+// location every interval. The poller bypasses the client's cache but
+// fills it, so other users of a caching client share the poller's requests.
+func NewPoller(client *illapaca.Client, locations []string, days int, interval time.Duration) *Poller {
+	return &Poller{
+		client:    client,
+		locations: locations,
+		days:      days,
+		interval:  interval,
+		snapshots: map[string]Snapshot{},
+	}
+}
+
+// Run refreshes every location immediately and then every interval until
+// ctx is done
+func (p *Poller) Run(ctx context.Context) {
+	p.Refresh(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh fetches every location once. A failed fetch keeps the previous
+// data and records the error.
+func (p *Poller) Refresh(ctx context.Context) {
+	for _, location := range p.locations {
+		data, err := p.client.RefreshWeather(ctx, location, p.days)
+
+		p.mu.Lock()
+		snapshot := p.snapshots[location]
+		snapshot.Location = location
+		snapshot.Err = err
+		if err == nil {
+			snapshot.Data = data
+			snapshot.FetchedAt = time.Now()
+		}
+		p.snapshots[location] = snapshot
+		p.mu.Unlock()
+	}
+}
+
+// Snapshots returns the latest snapshot of every location, in the order
+// the locations were given
+func (p *Poller) Snapshots() []Snapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	snapshots := make([]Snapshot, 0, len(p.locations))
+	for _, location := range p.locations {
+		if s, ok := p.snapshots[location]; ok {
+			snapshots = append(snapshots, s)
+		}
+	}
+	return snapshots
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca"
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+)
+
+func TestPollerBypassesCache(t *testing.T) {
+	var hits atomic.Int32
+	upstream := apitest.Handler()
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		upstream.ServeHTTP(w, r)
+	}))
+	defer provider.Close()
+
+	// The cache outlives the test, so every tick after the first would be
+	// a hit if the poller read from it
+	cache := illapaca.NewMemoryCache(time.Hour)
+	client, err := illapaca.New(
+		illapaca.WithProvider(api.ProviderWeatherAPI, apitest.APIKey),
+		illapaca.WithBaseURL(provider.URL),
+		illapaca.WithCache(cache),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	poller := NewPoller(client, []string{apitest.LocationOK}, 3, 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		poller.Run(ctx)
+		close(done)
+	}()
+
+	// The first refresh plus two ticks
+	deadline := time.Now().Add(5 * time.Second)
+	for hits.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if n := hits.Load(); n < 3 {
+		t.Fatalf("provider requests = %d after two ticks, want 3", n)
+	}
+	if _, ok := cache.Get(illapaca.CacheKey(api.ProviderWeatherAPI, apitest.LocationOK, 3)); !ok {
+		t.Error("poller did not fill the client's cache")
+	}
+}
@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/biferdou/illapaca/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// likelyRain is the chance of rain from which an hour is reported as wet
+// in accessible rain summaries
+const likelyRain = 50
+
+// mark returns a decorative prefix such as an emoji, or nothing in
+// accessible mode, where screen readers would read its name aloud
+func (r *Renderer) mark(prefix string) string {
+	if r.settings.Accessible {
+		return ""
+	}
+	return prefix
+}
+
+// table is a tablewriter table that renders as labeled lines instead in
+// accessible mode
+type table struct {
+	*tablewriter.Table
+	r      *Renderer
+	header []string
+	rows   [][]string
+}
+
+// newTable creates a borderless table with the given header
+func (r *Renderer) newTable(header []string) *table {
+	t := tablewriter.NewWriter(r.w)
+	t.SetHeader(header)
+	t.SetBorder(false)
+	t.SetCenterSeparator("")
+	t.SetColumnSeparator("  ") // Use double spaces instead of pipes
+	t.SetRowSeparator("")
+	return &table{Table: t, r: r, header: header}
+}
+
+// Append adds a row
+func (t *table) Append(row []string) {
+	t.rows = append(t.rows, row)
+	t.Table.Append(row)
+}
+
+// Render writes the table, or in accessible mode one line per row led by
+// its first cell, e.g. "2025-10-17: Max 16.5°C, Min 7.5°C". Empty cells
+// are left out.
+func (t *table) Render() {
+	if !t.r.settings.Accessible {
+		t.Table.Render()
+		return
+	}
+	for _, row := range t.rows {
+		var fields []string
+		for i, cell := range row[1:] {
+			cell = strings.TrimSpace(cell)
+			if cell == "" || cell == "--" || i+1 >= len(t.header) {
+				continue
+			}
+			fields = append(fields, t.header[i+1]+" "+cell)
+		}
+		fmt.Fprintf(t.r.w, "%s: %s\n", strings.TrimSpace(row[0]), strings.Join(fields, ", "))
+	}
+}
+
+// describeTemperature summarizes a day's hourly temperatures in a sentence,
+// e.g. "Temperature rises from 12°C at 06:00 to a peak of 21°C at 15:00,
+// then falls to 14°C at 21:00." It returns "" if no reading is usable.
+func (r *Renderer) describeTemperature(hours []model.Hour) string {
+	var temps []float64
+	var times []string
+	for _, hour := range hours {
+		if math.IsNaN(hour.TempC) || math.IsInf(hour.TempC, 0) {
+			continue
+		}
+		temps = append(temps, hour.TempC)
+		times = append(times, hourLabel(hour.Time))
+	}
+	if len(temps) == 0 {
+		return ""
+	}
+
+	low, high := 0, 0
+	for i, t := range temps {
+		if t < temps[low] {
+			low = i
+		}
+		if t > temps[high] {
+			high = i
+		}
+	}
+	last := len(temps) - 1
+	if math.Round(temps[high]) == math.Round(temps[low]) {
+		return r.tf("Temperature holds steady around %.0f°C from %s to %s.",
+			temps[0], times[0], times[last])
+	}
+
+	// Walk from the first reading through the extremes, in time order, to
+	// the last
+	points := []int{0}
+	for _, i := range []int{min(low, high), max(low, high), last} {
+		if i > points[len(points)-1] {
+			points = append(points, i)
+		}
+	}
+
+	var b strings.Builder
+	for j := 1; j < len(points); j++ {
+		from, to := points[j-1], points[j]
+		verb := r.t("holds")
+		if temps[to] > temps[from] {
+			verb = r.t("rises")
+		} else if temps[to] < temps[from] {
+			verb = r.t("falls")
+		}
+		if j == 1 {
+			b.WriteString(r.tf("Temperature %s from %.0f°C at %s", verb, temps[from], times[from]))
+		} else {
+			b.WriteString(", " + r.tf("then %s", verb))
+		}
+		switch to {
+		case high:
+			b.WriteString(" " + r.tf("to a peak of %.0f°C at %s", temps[to], times[to]))
+		case low:
+			b.WriteString(" " + r.tf("to a low of %.0f°C at %s", temps[to], times[to]))
+		default:
+			b.WriteString(" " + r.tf("to %.0f°C at %s", temps[to], times[to]))
+		}
+	}
+	b.WriteString(".")
+	return b.String()
+}
+
+// describeRain summarizes a day's hourly chances of rain in a sentence,
+// naming the peak and the spells when rain is likely. It returns "" if
+// there are no hours.
+func (r *Renderer) describeRain(hours []model.Hour) string {
+	if len(hours) == 0 {
+		return ""
+	}
+
+	peak := 0
+	for i, hour := range hours {
+		if hour.ChanceOfRain > hours[peak].ChanceOfRain {
+			peak = i
+		}
+	}
+	chance := hours[peak].ChanceOfRain
+	switch {
+	case chance == 0:
+		return r.t("No rain expected.")
+	case chance < likelyRain:
+		return r.tf("Chance of rain stays below %d%%, peaking at %d%% at %s.",
+			likelyRain, chance, hourLabel(hours[peak].Time))
+	}
+
+	var spells []string
+	for i := 0; i < len(hours); i++ {
+		if hours[i].ChanceOfRain < likelyRain {
+			continue
+		}
+		start := i
+		for i+1 < len(hours) && hours[i+1].ChanceOfRain >= likelyRain {
+			i++
+		}
+		if start == i {
+			spells = append(spells, r.tf("at %s", hourLabel(hours[i].Time)))
+		} else {
+			spells = append(spells, r.tf("from %s to %s", hourLabel(hours[start].Time), hourLabel(hours[i].Time)))
+		}
+	}
+	return r.tf("Chance of rain peaks at %d%% at %s, and is %d%% or higher %s.",
+		chance, hourLabel(hours[peak].Time), likelyRain, strings.Join(spells, " "+r.t("and")+" "))
+}
+
+// displaySummary writes a chart's summary, or a placeholder if it is empty
+func (r *Renderer) displaySummary(summary, placeholder string) {
+	if summary == "" {
+		r.placeholder(placeholder)
+		return
+	}
+	r.paint(RoleText).Fprintln(r.w, summary)
+	fmt.Fprintln(r.w)
+}
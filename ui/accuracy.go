@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/biferdou/illapaca/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// leadLabel names a forecast lead time
+func (r *Renderer) leadLabel(days int) string {
+	switch days {
+	case 0:
+		return r.t("Same day")
+	case 1:
+		return r.t("1 day")
+	default:
+		return r.tf("%d days", days)
+	}
+}
+
+// DisplayAccuracy outputs forecast errors per provider and lead time
+func (r *Renderer) DisplayAccuracy(location string, results []model.ForecastAccuracy) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.tf("Forecast Accuracy: %s", location))
+	fmt.Fprintln(r.w)
+
+	if len(results) == 0 {
+		r.placeholder("Not enough recorded forecasts and observations yet; run 'illapaca log' regularly")
+		return
+	}
+
+	table := r.newTable([]string{r.t("Provider"), r.t("Lead"), r.t("Samples"), r.t("Max MAE"), r.t("Max Bias"), r.t("Min MAE"), r.t("Min Bias"), r.t("Rain MAE"), r.t("Rain Bias")})
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT,
+		tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT})
+
+	for _, a := range results {
+		table.Append([]string{
+			a.Provider,
+			r.leadLabel(a.LeadDays),
+			fmt.Sprintf("%d", a.Samples),
+			r.tf("%.1f°C", a.MaxTemp.MAE),
+			r.formatDifference(a.MaxTemp.Bias, "°C"),
+			r.tf("%.1f°C", a.MinTemp.MAE),
+			r.formatDifference(a.MinTemp.Bias, "°C"),
+			r.tf("%.0f%%", a.Rain.MAE),
+			r.formatDifference(a.Rain.Bias, "%"),
+		})
+	}
+	table.Render()
+	fmt.Fprintln(r.w)
+
+	r.displayAccuracySummary(results)
+}
+
+// displayAccuracySummary names the provider with the lowest temperature
+// error over all lead times, when more than one has been recorded
+func (r *Renderer) displayAccuracySummary(results []model.ForecastAccuracy) {
+	type total struct {
+		err     float64
+		samples int
+	}
+	var providers []string
+	totals := map[string]*total{}
+	for _, a := range results {
+		t := totals[a.Provider]
+		if t == nil {
+			t = &total{}
+			totals[a.Provider] = t
+			providers = append(providers, a.Provider)
+		}
+		t.err += (a.MaxTemp.MAE + a.MinTemp.MAE) / 2 * float64(a.Samples)
+		t.samples += a.Samples
+	}
+	if len(providers) < 2 {
+		return
+	}
+
+	best := providers[0]
+	for _, p := range providers[1:] {
+		if totals[p].err/float64(totals[p].samples) < totals[best].err/float64(totals[best].samples) {
+			best = p
+		}
+	}
+
+	analysisColor := r.paint(RoleAccent)
+	analysisColor.Fprintf(r.w, "%s%s\n\n", r.mark("📊 "), r.tf("%s has the lowest temperature error here (%.1f°C on average)",
+		best, totals[best].err/float64(totals[best].samples)))
+}
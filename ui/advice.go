@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/comfort"
+	"github.com/biferdou/illapaca/model"
+)
+
+// adviceHours is how far ahead advice looks for rain and sun
+const adviceHours = 12
+
+// dewPointFeel describes how muggy a dew point in °C feels
+func dewPointFeel(dewPointC float64) string {
+	switch {
+	case dewPointC < 10:
+		return "dry"
+	case dewPointC < 16:
+		return "comfortable"
+	case dewPointC < 18:
+		return "slightly humid"
+	case dewPointC < 21:
+		return "humid"
+	case dewPointC < 24:
+		return "muggy"
+	default:
+		return "oppressive"
+	}
+}
+
+// advice is one recommendation and the role that colors it
+type advice struct {
+	icon, label, text string
+	role              Role
+}
+
+// DisplayAdvice outputs comfort metrics derived from the current weather
+// and advice on layers, an umbrella, sunscreen and hydration
+func (r *Renderer) DisplayAdvice(data *model.WeatherData) {
+	subheading := r.paint(RoleSubheading)
+	subheading.Fprintln(r.w, r.t("Comfort and Advice"))
+	fmt.Fprintln(r.w)
+
+	metrics := comfort.Compute(data.Current)
+	labelStyle := r.paint(RoleLabel)
+	valueStyle := r.paint(RoleValue)
+
+	labels := []string{r.t("Dew point:"), r.t("Heat index:"), r.t("Wind chill:"), r.t("Humidex:")}
+	width := labelWidth(labels...)
+	values := []string{
+		r.tf("%.1f°C", metrics.DewPointC) + " (" + r.t(dewPointFeel(metrics.DewPointC)) + ")",
+		r.tf("%.1f°C", metrics.HeatIndexC),
+		r.tf("%.1f°C", metrics.WindChillC),
+		r.tf("%.1f°C", metrics.HumidexC),
+	}
+	for i, label := range labels {
+		labelStyle.Fprintf(r.w, "%-*s", width, label)
+		valueStyle.Fprintln(r.w, values[i])
+	}
+	fmt.Fprintln(r.w)
+
+	items := r.advise(data, metrics)
+	itemLabels := make([]string, len(items))
+	for i, item := range items {
+		itemLabels[i] = item.label
+	}
+	width = labelWidth(itemLabels...)
+	for _, item := range items {
+		fmt.Fprint(r.w, r.mark(item.icon+" "))
+		labelStyle.Fprintf(r.w, "%-*s", width, item.label)
+		r.paint(item.role).Fprintln(r.w, item.text)
+	}
+	fmt.Fprintln(r.w)
+}
+
+// advise recommends what to wear and take from the current weather and
+// the coming hours
+func (r *Renderer) advise(data *model.WeatherData, metrics comfort.Metrics) []advice {
+	hours := upcomingHours(data, adviceHours)
+	return []advice{
+		r.layersAdvice(data.Current, metrics),
+		r.umbrellaAdvice(data, hours),
+		r.sunscreenAdvice(data, hours),
+		r.hydrationAdvice(data.Current, metrics),
+	}
+}
+
+// layersAdvice picks clothing for the feels-like temperature, adding a
+// windproof layer in strong wind
+func (r *Renderer) layersAdvice(current model.CurrentWeather, metrics comfort.Metrics) advice {
+	feel := math.Min(current.FeelsLikeC, metrics.WindChillC)
+	a := advice{icon: "🧥", label: r.t("Layers:"), role: RoleValue}
+	switch {
+	case feel < -10:
+		a.text, a.role = r.t("Heavy winter coat, hat, gloves and scarf"), RoleDanger
+	case feel < 0:
+		a.text, a.role = r.t("Winter coat, hat and gloves"), RoleWarning
+	case feel < 10:
+		a.text = r.t("Warm jacket over a sweater")
+	case feel < 16:
+		a.text = r.t("Light jacket or sweater")
+	case feel < 22:
+		a.text = r.t("Long sleeves or a light layer")
+	case feel < 27:
+		a.text = r.t("T-shirt weather")
+	default:
+		a.text = r.t("Light, breathable clothing")
+	}
+	if current.WindKph >= 30 {
+		a.text += "; " + r.t("add a windproof layer")
+	}
+	return a
+}
+
+// umbrellaAdvice weighs rain now and the chance of rain in the coming
+// hours, or today's chance when there are no hourly forecasts
+func (r *Renderer) umbrellaAdvice(data *model.WeatherData, hours []model.Hour) advice {
+	chance := 0
+	for _, hour := range hours {
+		chance = max(chance, hour.ChanceOfRain)
+	}
+	if len(hours) == 0 && len(data.Forecast.ForecastDay) > 0 {
+		chance = data.Forecast.ForecastDay[0].Day.DailyChanceOfRain
+	}
+
+	a := advice{icon: "☂️", label: r.t("Umbrella:")}
+	switch {
+	case data.Current.PrecipMm > 0:
+		a.text, a.role = r.t("Take one, it is raining"), RoleWarning
+	case chance >= likelyRain:
+		a.text, a.role = r.tf("Take one, %d%% chance of rain in the coming hours", chance), RoleWarning
+	case chance >= 30:
+		a.text, a.role = r.tf("Worth packing, %d%% chance of rain in the coming hours", chance), RoleCaution
+	default:
+		a.text, a.role = r.t("Not needed"), RoleOK
+	}
+	return a
+}
+
+// sunscreenAdvice follows the WHO's sun protection advice for the highest
+// UV index now or in the coming hours
+func (r *Renderer) sunscreenAdvice(data *model.WeatherData, hours []model.Hour) advice {
+	a := advice{icon: "🧴", label: r.t("Sunscreen:")}
+	if !api.SuppliesUV(data.Provider) {
+		a.text, a.role = r.tf("UV index not available from %s", data.Provider), RoleMuted
+		return a
+	}
+
+	uv := data.Current.UV
+	for _, hour := range hours {
+		uv = math.Max(uv, hour.UV)
+	}
+	// The index is reported in whole steps
+	uv = math.Round(uv)
+
+	switch {
+	case uv >= 8:
+		a.text, a.role = r.tf("SPF 50+, hat and sunglasses; avoid the midday sun (UV %.0f)", uv), RoleDanger
+	case uv >= 6:
+		a.text, a.role = r.tf("SPF 30+, hat and sunglasses (UV %.0f)", uv), RoleWarning
+	case uv >= 3:
+		a.text, a.role = r.tf("SPF 30 if you are out for long (UV %.0f)", uv), RoleCaution
+	default:
+		a.text, a.role = r.tf("Not needed (UV %.0f)", uv), RoleOK
+	}
+	return a
+}
+
+// hydrationAdvice goes by the heat index, or the humidex where it is
+// higher, as sweat evaporates less in humid heat
+func (r *Renderer) hydrationAdvice(current model.CurrentWeather, metrics comfort.Metrics) advice {
+	heat := math.Max(metrics.HeatIndexC, metrics.HumidexC)
+	heat = math.Max(heat, current.TempC)
+
+	a := advice{icon: "💧", label: r.t("Hydration:")}
+	switch {
+	case heat >= 40:
+		a.text, a.role = r.tf("Drink water often and rest in the shade (feels like %.0f°C)", heat), RoleDanger
+	case heat >= 32:
+		a.text, a.role = r.tf("Drink more water than usual (feels like %.0f°C)", heat), RoleWarning
+	case heat >= 27:
+		a.text, a.role = r.t("Carry a water bottle"), RoleCaution
+	default:
+		a.text, a.role = r.t("Drink as usual"), RoleOK
+	}
+	return a
+}
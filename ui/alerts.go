@@ -3,59 +3,66 @@ package ui
 import (
 	"fmt"
 
-	"github.com/biferdou/illapaca/config"
+	"github.com/biferdou/illapaca/i18n"
 	"github.com/biferdou/illapaca/model"
-	"github.com/fatih/color"
 )
 
 // CheckAlerts checks weather against alert thresholds with clean styling
-func CheckAlerts(data *model.WeatherData) {
-	thresholds := config.AppConfig.AlertThresholds
-	alerts := getAlerts(data, thresholds)
+func (r *Renderer) CheckAlerts(data *model.WeatherData) {
+	alerts := LocalizedAlerts(data, r.settings.AlertThresholds, r.locale())
 
 	if len(alerts) == 0 {
 		return
 	}
 
 	// Create alert section with cleaner styling
-	alertTitle := color.New(color.FgHiRed, color.Bold)
-	alertText := color.New(color.FgHiRed)
+	alertTitle := r.paint(RoleAlert)
+	alertText := r.paint(RoleAlertText)
 
-	alertTitle.Println("⚠️  WEATHER ALERTS  ⚠️")
-	fmt.Println()
+	if r.settings.Accessible {
+		alertTitle.Fprintln(r.w, r.t("Weather alerts:"))
+	} else {
+		alertTitle.Fprintf(r.w, "⚠️  %s  ⚠️\n", r.t("WEATHER ALERTS"))
+	}
+	fmt.Fprintln(r.w)
 
 	// Display each alert
 	for _, alertMsg := range alerts {
-		alertText.Printf("• %s\n", alertMsg)
+		alertText.Fprintf(r.w, "%s%s\n", r.mark("• "), alertMsg)
 	}
-	fmt.Println()
+	fmt.Fprintln(r.w)
+}
+
+// Alerts returns the alert messages for data under the given thresholds
+func Alerts(data *model.WeatherData, thresholds model.AlertThresholds) []string {
+	return LocalizedAlerts(data, thresholds, i18n.English)
 }
 
-// getAlerts returns a list of alert messages for the given weather data
-func getAlerts(data *model.WeatherData, thresholds config.AlertThresholds) []string {
+// LocalizedAlerts returns the alert messages for data under the given
+// thresholds, in locale l
+func LocalizedAlerts(data *model.WeatherData, thresholds model.AlertThresholds, l *i18n.Locale) []string {
 	var alerts []string
 
-	// Same implementation as before
 	if data.Current.TempC > thresholds.HighTemp {
-		alerts = append(alerts, fmt.Sprintf("High temperature (%.1f°C) exceeds threshold (%.1f°C)",
+		alerts = append(alerts, l.Sprintf("High temperature (%.1f°C) exceeds threshold (%.1f°C)",
 			data.Current.TempC, thresholds.HighTemp))
 	}
 
 	if data.Current.TempC < thresholds.LowTemp {
-		alerts = append(alerts, fmt.Sprintf("Low temperature (%.1f°C) below threshold (%.1f°C)",
+		alerts = append(alerts, l.Sprintf("Low temperature (%.1f°C) below threshold (%.1f°C)",
 			data.Current.TempC, thresholds.LowTemp))
 	}
 
 	if data.Current.WindKph > thresholds.WindSpeed {
-		alerts = append(alerts, fmt.Sprintf("High wind speed (%.1f km/h) exceeds threshold (%.1f km/h)",
+		alerts = append(alerts, l.Sprintf("High wind speed (%.1f km/h) exceeds threshold (%.1f km/h)",
 			data.Current.WindKph, thresholds.WindSpeed))
 	}
 
 	// Check forecast for precipitation
 	for _, day := range data.Forecast.ForecastDay {
 		if day.Day.DailyChanceOfRain > int(thresholds.Precipitation) {
-			alerts = append(alerts, fmt.Sprintf("High chance of rain (%d%%) on %s exceeds threshold (%.0f%%)",
-				day.Day.DailyChanceOfRain, day.Date, thresholds.Precipitation))
+			alerts = append(alerts, l.Sprintf("High chance of rain (%d%%) on %s exceeds threshold (%.0f%%)",
+				day.Day.DailyChanceOfRain, localDate(l, day.Date), thresholds.Precipitation))
 		}
 	}
 
@@ -63,14 +70,14 @@ func getAlerts(data *model.WeatherData, thresholds config.AlertThresholds) []str
 }
 
 // DisplayAlertSettings shows the current alert threshold settings
-func DisplayAlertSettings(thresholds config.AlertThresholds) {
-	settingsTitle := color.New(color.FgHiYellow, color.Bold)
-	settingsTitle.Println("Alert Threshold Settings:")
-	fmt.Println()
-
-	fmt.Printf("High Temperature: %.1f°C\n", thresholds.HighTemp)
-	fmt.Printf("Low Temperature: %.1f°C\n", thresholds.LowTemp)
-	fmt.Printf("Precipitation Chance: %.0f%%\n", thresholds.Precipitation)
-	fmt.Printf("Wind Speed: %.1f km/h\n", thresholds.WindSpeed)
-	fmt.Println()
+func (r *Renderer) DisplayAlertSettings(thresholds model.AlertThresholds) {
+	settingsTitle := r.paint(RoleTitle)
+	settingsTitle.Fprintln(r.w, r.t("Alert Threshold Settings:"))
+	fmt.Fprintln(r.w)
+
+	fmt.Fprintln(r.w, r.tf("High Temperature: %.1f°C", thresholds.HighTemp))
+	fmt.Fprintln(r.w, r.tf("Low Temperature: %.1f°C", thresholds.LowTemp))
+	fmt.Fprintln(r.w, r.tf("Precipitation Chance: %.0f%%", thresholds.Precipitation))
+	fmt.Fprintln(r.w, r.tf("Wind Speed: %.1f km/h", thresholds.WindSpeed))
+	fmt.Fprintln(r.w)
 }
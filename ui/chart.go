@@ -3,41 +3,42 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/biferdou/illapaca/model"
 	"github.com/fatih/color"
 )
 
-// getTemperatureColor returns a color based on temperature
-func getTemperatureColor(temp, minTemp, maxTemp float64) *color.Color {
-	// Calculate where this temperature falls in the range (0.0 to 1.0)
-	ratio := (temp - minTemp) / (maxTemp - minTemp)
-
-	// Create a color gradient from blue (cold) to red (hot)
-	if ratio < 0.2 {
-		return color.New(color.FgHiBlue)
-	} else if ratio < 0.4 {
-		return color.New(color.FgHiCyan)
-	} else if ratio < 0.6 {
-		return color.New(color.FgHiGreen)
-	} else if ratio < 0.8 {
-		return color.New(color.FgHiYellow)
-	} else {
-		return color.New(color.FgHiRed)
-	}
+// getTemperatureColor returns a color based on where temp falls between
+// minTemp and maxTemp, from cold to hot. A flat or invalid range has no
+// gradient and gets the middle color.
+func (r *Renderer) getTemperatureColor(temp, minTemp, maxTemp float64) *color.Color {
+	return r.paint(scaleRole(temperatureRoles, temp, minTemp, maxTemp))
 }
 
 // DisplayTemperatureChart renders a simple temperature chart
-func DisplayTemperatureChart(data *model.WeatherData) {
-	chartTitle := color.New(color.FgHiGreen, color.Bold)
-	chartTitle.Println("Temperature Trend (24 hours)")
-	fmt.Println()
+func (r *Renderer) DisplayTemperatureChart(data *model.WeatherData) {
+	chartTitle := r.paint(RoleChartTitle)
+	chartTitle.Fprintln(r.w, r.t("Temperature Trend (24 hours)"))
+	fmt.Fprintln(r.w)
+
+	if len(data.Forecast.ForecastDay) == 0 {
+		r.placeholder("No forecast data available")
+		return
+	}
 
 	// Get the first day's hourly forecast
 	hours := data.Forecast.ForecastDay[0].Hour
 
-	// Process data for every 3 hours (8 points total)
+	if r.settings.Accessible {
+		r.displaySummary(r.describeTemperature(hours), "No hourly temperatures available")
+		return
+	}
+
+	// Process data for every 3 hours (8 points total), skipping readings
+	// that cannot be plotted
 	var temps []float64
 	var times []string
 
@@ -45,10 +46,16 @@ func DisplayTemperatureChart(data *model.WeatherData) {
 		if len(temps) >= 8 {
 			break
 		}
+		if math.IsNaN(hours[i].TempC) || math.IsInf(hours[i].TempC, 0) {
+			continue
+		}
 		temps = append(temps, hours[i].TempC)
+		times = append(times, hourLabel(hours[i].Time))
+	}
 
-		t, _ := time.Parse("2006-01-02 15:04", hours[i].Time)
-		times = append(times, fmt.Sprintf("%02d:00", t.Hour()))
+	if len(temps) == 0 {
+		r.placeholder("No hourly temperatures available")
+		return
 	}
 
 	// Find min/max for scaling
@@ -62,7 +69,7 @@ func DisplayTemperatureChart(data *model.WeatherData) {
 		}
 	}
 
-	// Add some padding
+	// Add some padding, which also keeps a flat series off the edges
 	min = min - 1
 	max = max + 1
 
@@ -70,17 +77,17 @@ func DisplayTemperatureChart(data *model.WeatherData) {
 	normalizedTemps := make([]int, len(temps))
 	for i, t := range temps {
 		// Scale to 0-10 range
-		normalizedTemps[i] = int((t - min) * 10 / (max - min))
+		normalizedTemps[i] = clamp(int((t-min)*10/(max-min)), 0, 10)
 	}
 
 	// Calculate total chart width
 	chartWidth := 88
 
 	// Print the chart
-	fmt.Println("     ┌" + repeatChar("─", chartWidth) + "┐")
+	fmt.Fprintln(r.w, "     ┌"+repeatChar("─", chartWidth)+"┐")
 
 	// Print the header row with time labels
-	fmt.Print("     │")
+	fmt.Fprint(r.w, "     │")
 
 	// Calculate space for each time column to ensure alignment
 	timeColWidth := chartWidth / len(times)
@@ -91,15 +98,15 @@ func DisplayTemperatureChart(data *model.WeatherData) {
 		leftPad := padding / 2
 		rightPad := padding - leftPad
 
-		fmt.Print(repeatChar(" ", leftPad))
-		fmt.Print(time)
-		fmt.Print(repeatChar(" ", rightPad))
+		fmt.Fprint(r.w, repeatChar(" ", leftPad))
+		fmt.Fprint(r.w, time)
+		fmt.Fprint(r.w, repeatChar(" ", rightPad))
 	}
-	fmt.Println("│")
+	fmt.Fprintln(r.w, "│")
 
 	// Print chart lines
 	for row := 10; row >= 0; row-- {
-		fmt.Print("     │")
+		fmt.Fprint(r.w, "     │")
 
 		// Print data points with calculated spacing
 		for i, level := range normalizedTemps {
@@ -108,44 +115,49 @@ func DisplayTemperatureChart(data *model.WeatherData) {
 			rightPad := padding - leftPad
 
 			if level == row {
-				tempColor := getTemperatureColor(temps[i], min, max)
-				fmt.Print(repeatChar(" ", leftPad))
-				tempColor.Print("•")
-				fmt.Print(repeatChar(" ", rightPad))
+				tempColor := r.getTemperatureColor(temps[i], min, max)
+				fmt.Fprint(r.w, repeatChar(" ", leftPad))
+				tempColor.Fprint(r.w, "•")
+				fmt.Fprint(r.w, repeatChar(" ", rightPad))
 			} else if level > row {
-				tempColor := getTemperatureColor(temps[i], min, max)
-				fmt.Print(repeatChar(" ", leftPad))
-				tempColor.Print("│")
-				fmt.Print(repeatChar(" ", rightPad))
+				tempColor := r.getTemperatureColor(temps[i], min, max)
+				fmt.Fprint(r.w, repeatChar(" ", leftPad))
+				tempColor.Fprint(r.w, "│")
+				fmt.Fprint(r.w, repeatChar(" ", rightPad))
 			} else {
-				fmt.Print(repeatChar(" ", timeColWidth))
+				fmt.Fprint(r.w, repeatChar(" ", timeColWidth))
 			}
 		}
 
 		// Add temperature scale on the right side
 		if row == 10 {
-			fmt.Printf("│ %.1f°C", max)
+			fmt.Fprint(r.w, "│ "+r.tf("%.1f°C", max))
 		} else if row == 0 {
-			fmt.Printf("│ %.1f°C", min)
+			fmt.Fprint(r.w, "│ "+r.tf("%.1f°C", min))
 		} else if row == 5 {
 			midTemp := (max + min) / 2
-			fmt.Printf("│ %.1f°C", midTemp)
+			fmt.Fprint(r.w, "│ "+r.tf("%.1f°C", midTemp))
 		} else {
-			fmt.Print("│")
+			fmt.Fprint(r.w, "│")
 		}
 
-		fmt.Println()
+		fmt.Fprintln(r.w)
 	}
 
-	fmt.Println("     └" + repeatChar("─", chartWidth) + "┘")
-	fmt.Println()
+	fmt.Fprintln(r.w, "     └"+repeatChar("─", chartWidth)+"┘")
+	fmt.Fprintln(r.w)
 }
 
 // DisplayPrecipitationChart renders a simple precipitation chance chart
-func DisplayPrecipitationChart(day model.ForecastDay) {
-	chartTitle := color.New(color.FgHiBlue, color.Bold)
-	chartTitle.Println("Precipitation Chance (24 hours)")
-	fmt.Println()
+func (r *Renderer) DisplayPrecipitationChart(day model.ForecastDay) {
+	chartTitle := r.paint(RoleChartTitle)
+	chartTitle.Fprintln(r.w, r.t("Precipitation Chance (24 hours)"))
+	fmt.Fprintln(r.w)
+
+	if r.settings.Accessible {
+		r.displaySummary(r.describeRain(day.Hour), "No hourly precipitation data available")
+		return
+	}
 
 	// Collect data for every 3 hours
 	var chances []int
@@ -156,19 +168,22 @@ func DisplayPrecipitationChart(day model.ForecastDay) {
 			break
 		}
 		chances = append(chances, day.Hour[i].ChanceOfRain)
+		times = append(times, hourLabel(day.Hour[i].Time))
+	}
 
-		t, _ := time.Parse("2006-01-02 15:04", day.Hour[i].Time)
-		times = append(times, fmt.Sprintf("%02d:00", t.Hour()))
+	if len(chances) == 0 {
+		r.placeholder("No hourly precipitation data available")
+		return
 	}
 
 	// Calculate total chart width
 	chartWidth := 88
 
 	// Print the chart header
-	fmt.Println("     ┌" + repeatChar("─", chartWidth) + "┐")
+	fmt.Fprintln(r.w, "     ┌"+repeatChar("─", chartWidth)+"┐")
 
 	// Print the header row with time labels
-	fmt.Print("     │")
+	fmt.Fprint(r.w, "     │")
 
 	// Calculate space for each time column to ensure alignment
 	timeColWidth := chartWidth / len(times)
@@ -179,16 +194,16 @@ func DisplayPrecipitationChart(day model.ForecastDay) {
 		leftPad := padding / 2
 		rightPad := padding - leftPad
 
-		fmt.Print(repeatChar(" ", leftPad))
-		fmt.Print(time)
-		fmt.Print(repeatChar(" ", rightPad))
+		fmt.Fprint(r.w, repeatChar(" ", leftPad))
+		fmt.Fprint(r.w, time)
+		fmt.Fprint(r.w, repeatChar(" ", rightPad))
 	}
-	fmt.Println("│")
+	fmt.Fprintln(r.w, "│")
 
 	// Print chart
 	rainLevels := []int{100, 80, 60, 40, 20, 0}
 	for i, level := range rainLevels {
-		fmt.Print("     │")
+		fmt.Fprint(r.w, "     │")
 
 		// Print precipitation bars with calculated spacing
 		for _, chance := range chances {
@@ -203,53 +218,60 @@ func DisplayPrecipitationChart(day model.ForecastDay) {
 
 				if chance >= 80 {
 					symbol = "█"
-					rainColor = color.New(color.FgHiBlue, color.Bold)
 				} else if chance >= 60 {
 					symbol = "▓"
-					rainColor = color.New(color.FgBlue)
 				} else if chance >= 40 {
 					symbol = "▒"
-					rainColor = color.New(color.FgHiCyan)
 				} else if chance >= 20 {
 					symbol = "░"
-					rainColor = color.New(color.FgCyan)
 				} else {
 					symbol = "·"
-					rainColor = color.New(color.FgHiWhite)
 				}
+				rainColor = r.paint(scaleRole(rainRoles, float64(chance), 0, 100))
 
 				// Print bar element with spacing
-				fmt.Print(repeatChar(" ", leftPad))
-				rainColor.Print(symbol)
-				fmt.Print(repeatChar(" ", rightPad))
+				fmt.Fprint(r.w, repeatChar(" ", leftPad))
+				rainColor.Fprint(r.w, symbol)
+				fmt.Fprint(r.w, repeatChar(" ", rightPad))
 			} else {
-				fmt.Print(repeatChar(" ", timeColWidth))
+				fmt.Fprint(r.w, repeatChar(" ", timeColWidth))
 			}
 		}
 
 		// Add precipitation scale on the right side
 		if i == 0 {
-			fmt.Print("│ 100%")
+			fmt.Fprint(r.w, "│ 100%")
 		} else if i == len(rainLevels)-1 {
-			fmt.Print("│ 0%")
+			fmt.Fprint(r.w, "│ 0%")
 		} else if i == len(rainLevels)/2 {
-			fmt.Print("│ 50%")
+			fmt.Fprint(r.w, "│ 50%")
 		} else {
-			fmt.Print("│")
+			fmt.Fprint(r.w, "│")
 		}
 
-		fmt.Println()
+		fmt.Fprintln(r.w)
 	}
 
-	fmt.Println("     └" + repeatChar("─", chartWidth) + "┘")
-	fmt.Println()
+	fmt.Fprintln(r.w, "     └"+repeatChar("─", chartWidth)+"┘")
+	fmt.Fprintln(r.w)
+}
+
+// hourLabel formats an hourly timestamp as HH:00, or --:-- if it cannot
+// be parsed
+func hourLabel(timestamp string) string {
+	t, err := time.Parse("2006-01-02 15:04", timestamp)
+	if err != nil {
+		return "--:--"
+	}
+	return fmt.Sprintf("%02d:00", t.Hour())
+}
+
+// clamp limits v to the range [lo, hi]
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
 }
 
 // Helper function to repeat a character n times
 func repeatChar(char string, count int) string {
-	result := ""
-	for i := 0; i < count; i++ {
-		result += char
-	}
-	return result
+	return strings.Repeat(char, max(count, 0))
 }
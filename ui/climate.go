@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// calendarDay formats a YYYY-MM-DD date as "Oct 17", or as the
+// renderer's language writes it
+func (r *Renderer) calendarDay(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return r.locale().DayMonth(t)
+}
+
+// recordYear returns the year of a YYYY-MM-DD record date
+func recordYear(date string) string {
+	if len(date) < 4 {
+		return date
+	}
+	return date[:4]
+}
+
+// normalDifference describes temp against a normal, e.g. "3.2°C above
+// normal for Oct 17"
+func (r *Renderer) normalDifference(temp, normal float64, date string) string {
+	diff := temp - normal
+	switch {
+	case math.Abs(diff) < 0.05:
+		return r.tf("Normal for %s", r.calendarDay(date))
+	case diff > 0:
+		return r.tf("%.1f°C above normal for %s", diff, r.calendarDay(date))
+	default:
+		return r.tf("%.1f°C below normal for %s", -diff, r.calendarDay(date))
+	}
+}
+
+// displayClimateContext compares the current temperature with the normal
+// for the location's local date, if climate statistics are available
+func (r *Renderer) displayClimateContext(data *model.WeatherData) {
+	if len(data.Location.Localtime) < len("2006-01-02") {
+		return
+	}
+	date := data.Location.Localtime[:len("2006-01-02")]
+	day, ok := r.climate.Day(date)
+	if !ok {
+		return
+	}
+
+	temp := data.Current.TempC
+	note := r.normalDifference(temp, day.NormalTempC, date)
+	if temp > day.P90TempC {
+		note += ", " + r.t("unusually warm")
+	} else if temp < day.P10TempC {
+		note += ", " + r.t("unusually cold")
+	}
+	r.paint(RoleText).Fprintf(r.w, "%s%s\n", r.mark("📈 "), note)
+
+	record := r.paint(RoleAlert)
+	if day.RecordHighDate != "" && temp > day.RecordHighC {
+		record.Fprintln(r.w, r.mark("🏆 ")+r.tf("Record high for %s (previous %.1f°C in %s)",
+			r.calendarDay(date), day.RecordHighC, recordYear(day.RecordHighDate)))
+	}
+	if day.RecordLowDate != "" && temp < day.RecordLowC {
+		record.Fprintln(r.w, r.mark("🏆 ")+r.tf("Record low for %s (previous %.1f°C in %s)",
+			r.calendarDay(date), day.RecordLowC, recordYear(day.RecordLowDate)))
+	}
+}
+
+// forecastNormal returns the forecast column comparing a day's maximum with
+// the normal maximum, flagging forecast records
+func (r *Renderer) forecastNormal(day model.ForecastDay) string {
+	stats, ok := r.climate.Day(day.Date)
+	if !ok {
+		return "--"
+	}
+
+	cell := r.formatDifference(math.Round((day.Day.MaxTempC-stats.NormalMaxC)*10)/10, "°C")
+	high, low := "▲ "+r.t("record"), "▼ "+r.t("record")
+	if r.settings.Accessible {
+		high, low = r.t("record high"), r.t("record low")
+	}
+	if stats.RecordHighDate != "" && day.Day.MaxTempC > stats.RecordHighC {
+		cell += " " + high
+	} else if stats.RecordLowDate != "" && day.Day.MinTempC < stats.RecordLowC {
+		cell += " " + low
+	}
+	return cell
+}
+
+// DisplayClimate outputs the climate statistics of one calendar day
+func (r *Renderer) DisplayClimate(c *model.Climate, date string) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.tf("Climate for %s on %s", c.Location, r.calendarDay(date)))
+	fmt.Fprintln(r.w)
+
+	day, ok := c.Day(date)
+	if !ok {
+		r.placeholder("Not enough recorded days yet; run 'illapaca climate fetch' or 'illapaca log'")
+		return
+	}
+
+	labelStyle := r.paint(RoleLabel)
+	valueStyle := r.paint(RoleValue)
+	width := max(16, labelWidth(r.t("Normal:"), r.t("Normal high:"), r.t("Normal low:"),
+		r.t("Typical range:"), r.t("Record high:"), r.t("Record low:")))
+	line := func(label, format string, args ...interface{}) {
+		labelStyle.Fprintf(r.w, "%-*s", width, r.t(label))
+		valueStyle.Fprintln(r.w, r.tf(format, args...))
+	}
+
+	line("Normal:", "%.1f°C", day.NormalTempC)
+	line("Normal high:", "%.1f°C", day.NormalMaxC)
+	line("Normal low:", "%.1f°C", day.NormalMinC)
+	line("Typical range:", "%.1f°C to %.1f°C (10th to 90th percentile, median %.1f°C)",
+		day.P10TempC, day.P90TempC, day.P50TempC)
+	if day.RecordHighDate != "" {
+		line("Record high:", "%.1f°C on %s", day.RecordHighC, r.date(day.RecordHighDate))
+		line("Record low:", "%.1f°C on %s", day.RecordLowC, r.date(day.RecordLowDate))
+	}
+	fmt.Fprintln(r.w)
+	fmt.Fprintf(r.w, "%s\n\n", r.tf("Based on %d recorded days", day.Samples))
+}
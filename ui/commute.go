@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// Commute is a round trip made every workday, as times of day since
+// midnight
+type Commute struct {
+	Depart time.Duration // leaving the start for the destination
+	Return time.Duration // leaving the destination for home
+	Travel time.Duration // time on the way, each way
+}
+
+// hourSlot is the longest gap between forecast hours: OpenWeatherMap
+// forecasts every three hours
+const hourSlot = 3 * time.Hour
+
+// commuteLeg is one trip of a commute, with the forecast at each end
+type commuteLeg struct {
+	date          string
+	outbound      bool
+	leave, arrive time.Time
+	from, to      *model.Hour
+}
+
+// hourAt returns the forecast hour covering t: the latest one starting at
+// or before it, no more than hourSlot earlier
+func hourAt(data *model.WeatherData, t time.Time) *model.Hour {
+	var found *model.Hour
+	for i := range data.Forecast.ForecastDay {
+		for j := range data.Forecast.ForecastDay[i].Hour {
+			hour := &data.Forecast.ForecastDay[i].Hour[j]
+			start, err := time.Parse("2006-01-02 15:04", hour.Time)
+			if err != nil || start.After(t) || t.Sub(start) >= hourSlot {
+				continue
+			}
+			found = hour
+		}
+	}
+	return found
+}
+
+// commuteLegs returns the trips of each workday in the forecast that have
+// not yet started at the place they leave from. Trips with no forecast at
+// either end are left out.
+func commuteLegs(home, work *model.WeatherData, c Commute) []commuteLeg {
+	var legs []commuteLeg
+	for _, day := range home.Forecast.ForecastDay {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil || date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+		trips := []struct {
+			outbound bool
+			at       time.Duration
+			from, to *model.WeatherData
+		}{
+			{true, c.Depart, home, work},
+			{false, c.Return, work, home},
+		}
+		for _, trip := range trips {
+			leave := date.Add(trip.at)
+			if now, err := time.Parse("2006-01-02 15:04", trip.from.Location.Localtime); err == nil && leave.Before(now) {
+				continue
+			}
+			leg := commuteLeg{
+				date:     day.Date,
+				outbound: trip.outbound,
+				leave:    leave,
+				arrive:   leave.Add(c.Travel),
+			}
+			leg.from, leg.to = hourAt(trip.from, leg.leave), hourAt(trip.to, leg.arrive)
+			if leg.from != nil || leg.to != nil {
+				legs = append(legs, leg)
+			}
+		}
+	}
+	return legs
+}
+
+// DisplayCommute outputs the weather at both ends of every upcoming
+// workday trip between home and work, flagging likely rain and strong wind
+func (r *Renderer) DisplayCommute(home, work *model.WeatherData, c Commute) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.tf("Commute: %s to %s", home.Location.Name, work.Location.Name))
+	r.paint(RoleMuted).Fprintln(r.w, r.tf("Leaving at %s, returning at %s, %.0f minutes each way",
+		clockOf(c.Depart), clockOf(c.Return), c.Travel.Minutes()))
+	fmt.Fprintln(r.w)
+
+	legs := commuteLegs(home, work, c)
+	if len(legs) == 0 {
+		r.placeholder("No upcoming workday trips in the forecast")
+		return
+	}
+
+	table := r.newTable([]string{r.t("Day"), r.t("Trip"), r.t("Leave"), r.t("Arrive"), r.t("Watch Out")})
+	flagged := 0
+	for _, leg := range legs {
+		trip := r.t("Return")
+		if leg.outbound {
+			trip = r.t("Outbound")
+		}
+		flags := r.commuteFlags(leg)
+		if flags != "" {
+			flagged++
+		}
+		table.Append([]string{
+			r.t(leg.leave.Weekday().String()[:3]) + " " + r.calendarDay(leg.date),
+			trip,
+			r.commuteCell(leg.leave, leg.from),
+			r.commuteCell(leg.arrive, leg.to),
+			OrPlaceholder(flags),
+		})
+	}
+	table.Render()
+	fmt.Fprintln(r.w)
+
+	switch {
+	case flagged == 0 && len(legs) == 1:
+		r.paint(RoleOK).Fprintf(r.w, "%s%s\n\n", r.mark("✅ "), r.t("The trip looks dry and calm"))
+		return
+	case flagged == 0:
+		r.paint(RoleOK).Fprintf(r.w, "%s%s\n\n", r.mark("✅ "), r.tf("All %d trips look dry and calm", len(legs)))
+		return
+	}
+	r.paint(RoleCaution).Fprintf(r.w, "%s%s\n\n", r.mark("☂️ "), r.tf("%d of %d trips may be wet or windy", flagged, len(legs)))
+}
+
+// commuteCell describes the forecast for one end of a trip
+func (r *Renderer) commuteCell(at time.Time, hour *model.Hour) string {
+	if hour == nil {
+		return at.Format("15:04") + " --"
+	}
+	icon := r.icon(hour.Condition, hour.IsDay == 1)
+	if r.settings.Accessible {
+		return r.tf("%s %s, %.0f°C, rain %d%%", at.Format("15:04"), icon, hour.TempC, hour.ChanceOfRain)
+	}
+	return at.Format("15:04") + " " + icon + " " + r.tf("%.0f°C", hour.TempC) + " " + fmt.Sprintf("%d%%", hour.ChanceOfRain)
+}
+
+// commuteFlags names likely rain and wind at or above the alert threshold
+// at either end of a trip
+func (r *Renderer) commuteFlags(leg commuteLeg) string {
+	rain, wind := 0, 0.0
+	for _, hour := range []*model.Hour{leg.from, leg.to} {
+		if hour != nil {
+			rain = max(rain, hour.ChanceOfRain)
+			wind = max(wind, hour.WindKph)
+		}
+	}
+	var flags []string
+	if rain >= likelyRain {
+		flags = append(flags, r.tf("Rain %d%%", rain))
+	}
+	if limit := r.settings.AlertThresholds.WindSpeed; limit > 0 && wind >= limit {
+		flags = append(flags, r.tf("Wind %.0f km/h", wind))
+	}
+	return strings.Join(flags, ", ")
+}
+
+// clockOf formats a time of day since midnight as HH:MM
+func clockOf(d time.Duration) string {
+	return time.Time{}.Add(d).Format("15:04")
+}
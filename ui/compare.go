@@ -3,62 +3,61 @@ package ui
 import (
 	"fmt"
 	"math"
-	"os"
 
 	"github.com/biferdou/illapaca/model"
-	"github.com/fatih/color"
-	"github.com/olekukonko/tablewriter"
 )
 
+// compareFixedWidth is roughly the width of the comparison table's metric
+// and difference columns with their separators
+const compareFixedWidth = 44
+
 // DisplayLocationComparison shows a side-by-side comparison of two locations
-func DisplayLocationComparison(data1, data2 *model.WeatherData) {
+func (r *Renderer) DisplayLocationComparison(data1, data2 *model.WeatherData) {
 	// Styled header
-	printStyledHeader(data1, data2)
-	fmt.Println()
+	r.printStyledHeader(data1, data2)
+	fmt.Fprintln(r.w)
 
 	// Display comparison table
-	table := createComparisonTable(data1, data2)
+	table := r.createComparisonTable(data1, data2)
 	table.Render()
-	fmt.Println()
+	fmt.Fprintln(r.w)
 
 	// Display analysis
-	displayComparisonAnalysis(data1, data2)
+	r.displayComparisonAnalysis(data1, data2)
 }
 
 // printStyledHeader prints a styled header for the comparison
-func printStyledHeader(data1, data2 *model.WeatherData) {
-	comparisonTitle := color.New(color.FgHiBlue, color.Bold)
-	locationStyle := color.New(color.FgHiCyan, color.Bold)
-
-	comparisonTitle.Print("Location Comparison: ")
-	locationStyle.Printf("%s", data1.Location.Name)
-	comparisonTitle.Print(" vs ")
-	locationStyle.Printf("%s\n", data2.Location.Name)
-	fmt.Println(dash(40))
+func (r *Renderer) printStyledHeader(data1, data2 *model.WeatherData) {
+	comparisonTitle := r.paint(RoleTitle)
+	locationStyle := r.paint(RoleHeading)
+
+	comparisonTitle.Fprint(r.w, r.t("Location Comparison: "))
+	locationStyle.Fprintf(r.w, "%s", data1.Location.Name)
+	comparisonTitle.Fprint(r.w, " "+r.t("vs")+" ")
+	locationStyle.Fprintf(r.w, "%s\n", data2.Location.Name)
+	r.rule(40)
 }
 
 // createComparisonTable builds the comparison table for two locations
-func createComparisonTable(data1, data2 *model.WeatherData) *tablewriter.Table {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Metric", data1.Location.Name, data2.Location.Name, "Difference"})
-	table.SetBorder(false)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("  ") // Use double spaces instead of pipes
-	table.SetRowSeparator("")
-	table.SetHeaderColor(
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiBlueColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiMagentaColor},
-	)
-
-	// Add condition with icon and text
-	table.Append([]string{
-		"Condition",
-		data1.Current.Condition.Text + " " + GetConditionIcon(data1.Current.Condition.Text),
-		" " + data2.Current.Condition.Text + " " + GetConditionIcon(data2.Current.Condition.Text),
-		"  --",
-	})
+func (r *Renderer) createComparisonTable(data1, data2 *model.WeatherData) *table {
+	table := r.newTable([]string{r.t("Metric"), data1.Location.Name, data2.Location.Name, r.t("Difference")})
+	if r.colorEnabled() {
+		table.SetHeaderColor(
+			r.headerColors(RoleHeader),
+			r.headerColors(RoleHeading),
+			r.headerColors(RoleHeading),
+			r.headerColors(RoleHeader),
+		)
+	}
+
+	// Add condition with icon and text, or just the text in accessible mode
+	condition1 := r.conditionText(data1.Current.Condition) + " " + r.icon(data1.Current.Condition, data1.Current.IsDay == 1)
+	condition2 := " " + r.conditionText(data2.Current.Condition) + " " + r.icon(data2.Current.Condition, data2.Current.IsDay == 1)
+	if r.settings.Accessible {
+		condition1 = r.conditionText(data1.Current.Condition)
+		condition2 = r.conditionText(data2.Current.Condition)
+	}
+	table.Append([]string{r.t("Condition"), condition1, condition2, "  --"})
 
 	// Calculate differences
 	tempDiff := data1.Current.TempC - data2.Current.TempC
@@ -67,66 +66,76 @@ func createComparisonTable(data1, data2 *model.WeatherData) *tablewriter.Table {
 	windDiff := data1.Current.WindKph - data2.Current.WindKph
 
 	// Format differences with sign
-	tempDiffStr := formatDifference(tempDiff, "°C")
-	feelsLikeDiffStr := formatDifference(feelsLikeDiff, "°C")
-	humidityDiffStr := formatDifference(float64(humidityDiff), "%")
-	windDiffStr := formatDifference(windDiff, " km/h")
+	tempDiffStr := r.formatDifference(tempDiff, "°C")
+	feelsLikeDiffStr := r.formatDifference(feelsLikeDiff, "°C")
+	humidityDiffStr := r.formatDifference(float64(humidityDiff), "%")
+	windDiffStr := r.formatDifference(windDiff, " km/h")
 
 	// Build table rows
-	table.Append([]string{"Temperature",
-		fmt.Sprintf("%.1f°C", data1.Current.TempC),
-		fmt.Sprintf("%.1f°C", data2.Current.TempC),
+	table.Append([]string{r.t("Temperature"),
+		r.tf("%.1f°C", data1.Current.TempC),
+		r.tf("%.1f°C", data2.Current.TempC),
 		tempDiffStr})
 
-	table.Append([]string{"Feels Like",
-		fmt.Sprintf("%.1f°C", data1.Current.FeelsLikeC),
-		fmt.Sprintf("%.1f°C", data2.Current.FeelsLikeC),
+	table.Append([]string{r.t("Feels Like"),
+		r.tf("%.1f°C", data1.Current.FeelsLikeC),
+		r.tf("%.1f°C", data2.Current.FeelsLikeC),
 		feelsLikeDiffStr})
 
-	table.Append([]string{"Humidity",
+	table.Append([]string{r.t("Humidity"),
 		fmt.Sprintf("%d%%", data1.Current.Humidity),
 		fmt.Sprintf("%d%%", data2.Current.Humidity),
 		humidityDiffStr})
 
-	table.Append([]string{"Wind Speed",
-		fmt.Sprintf("%.1f km/h", data1.Current.WindKph),
-		fmt.Sprintf("%.1f km/h", data2.Current.WindKph),
+	table.Append([]string{r.t("Wind Speed"),
+		r.tf("%.1f km/h", data1.Current.WindKph),
+		r.tf("%.1f km/h", data2.Current.WindKph),
 		windDiffStr})
 
-	table.Append([]string{"Wind Direction",
-		data1.Current.WindDir,
-		data2.Current.WindDir,
+	table.Append([]string{r.t("Wind Direction"),
+		r.compass(data1.Current.WindDir),
+		r.compass(data2.Current.WindDir),
 		"--"})
 
-	table.Append([]string{"Precipitation",
-		fmt.Sprintf("%.1f mm", data1.Current.PrecipMm),
-		fmt.Sprintf("%.1f mm", data2.Current.PrecipMm),
+	table.Append([]string{r.t("Precipitation"),
+		r.tf("%.1f mm", data1.Current.PrecipMm),
+		r.tf("%.1f mm", data2.Current.PrecipMm),
 		"--"})
 
-	table.Append([]string{"Visibility",
-		fmt.Sprintf("%.1f km", data1.Current.VisKm),
-		fmt.Sprintf("%.1f km", data2.Current.VisKm),
+	table.Append([]string{r.t("Visibility"),
+		r.tf("%.1f km", data1.Current.VisKm),
+		r.tf("%.1f km", data2.Current.VisKm),
 		"--"})
 
-	table.Append([]string{"Local Time",
-		data1.Location.Localtime,
-		data2.Location.Localtime,
+	// The metric and difference columns take about compareFixedWidth
+	// columns, leaving the rest to the two locations' trends
+	width := r.sparkWidth(compareFixedWidth, 2)
+	if temp1, temp2 := r.tempTrend(upcomingHours(data1, 24), width), r.tempTrend(upcomingHours(data2, 24), width); temp1 != "" || temp2 != "" {
+		table.Append([]string{r.t("Temp (24h)"), temp1, temp2, "--"})
+	}
+	if rain1, rain2 := r.rainTrend(upcomingHours(data1, 24), width), r.rainTrend(upcomingHours(data2, 24), width); rain1 != "" || rain2 != "" {
+		table.Append([]string{r.t("Rain (24h)"), rain1, rain2, "--"})
+	}
+
+	table.Append([]string{r.t("Local Time"),
+		r.dateTime(data1.Location.Localtime),
+		r.dateTime(data2.Location.Localtime),
 		"--"})
 
 	return table
 }
 
 // displayComparisonAnalysis provides textual analysis of the comparison
-func displayComparisonAnalysis(data1, data2 *model.WeatherData) {
-	analysisColor := color.New(color.FgHiCyan)
+func (r *Renderer) displayComparisonAnalysis(data1, data2 *model.WeatherData) {
+	analysisColor := r.paint(RoleAccent)
 
 	// Temperature comparison
 	tempDiff := data1.Current.TempC - data2.Current.TempC
 	if math.Abs(tempDiff) > 3 {
 		if tempDiff > 0 {
-			analysisColor.Printf("📊 %s is %.1f°C warmer than %s\n", data1.Location.Name, tempDiff, data2.Location.Name)
+			analysisColor.Fprintln(r.w, r.mark("📊 ")+r.tf("%s is %.1f°C warmer than %s", data1.Location.Name, tempDiff, data2.Location.Name))
 		} else {
-			analysisColor.Printf("📊 %s is %.1f°C colder than %s\n", data1.Location.Name, -tempDiff, data2.Location.Name)
+			analysisColor.Fprintln(r.w, r.mark("📊 ")+r.tf("%s is %.1f°C colder than %s", data1.Location.Name, -tempDiff, data2.Location.Name))
 		}
 	}
 
@@ -134,9 +143,9 @@ func displayComparisonAnalysis(data1, data2 *model.WeatherData) {
 	humidityDiff := data1.Current.Humidity - data2.Current.Humidity
 	if math.Abs(float64(humidityDiff)) > 15 {
 		if humidityDiff > 0 {
-			analysisColor.Printf("💧 %s is more humid than %s\n", data1.Location.Name, data2.Location.Name)
+			analysisColor.Fprintln(r.w, r.mark("💧 ")+r.tf("%s is more humid than %s", data1.Location.Name, data2.Location.Name))
 		} else {
-			analysisColor.Printf("💧 %s is drier than %s\n", data1.Location.Name, data2.Location.Name)
+			analysisColor.Fprintln(r.w, r.mark("💧 ")+r.tf("%s is drier than %s", data1.Location.Name, data2.Location.Name))
 		}
 	}
 
@@ -144,20 +153,20 @@ func displayComparisonAnalysis(data1, data2 *model.WeatherData) {
 	windDiff := data1.Current.WindKph - data2.Current.WindKph
 	if math.Abs(windDiff) > 10 {
 		if windDiff > 0 {
-			analysisColor.Printf("🌬️  %s is windier than %s\n", data1.Location.Name, data2.Location.Name)
+			analysisColor.Fprintln(r.w, r.mark("🌬️  ")+r.tf("%s is windier than %s", data1.Location.Name, data2.Location.Name))
 		} else {
-			analysisColor.Printf("🌬️  %s is calmer than %s\n", data1.Location.Name, data2.Location.Name)
+			analysisColor.Fprintln(r.w, r.mark("🌬️  ")+r.tf("%s is calmer than %s", data1.Location.Name, data2.Location.Name))
 		}
 	}
 }
 
 // formatDifference formats a numeric difference with a sign and unit
-func formatDifference(diff float64, unit string) string {
+func (r *Renderer) formatDifference(diff float64, unit string) string {
 	if diff == 0 {
 		return "0" + unit
 	}
 	if diff > 0 {
-		return fmt.Sprintf("+%.1f%s", diff, unit)
+		return r.tf("+%.1f%s", diff, unit)
 	}
-	return fmt.Sprintf("%.1f%s", diff, unit)
+	return r.tf("%.1f%s", diff, unit)
 }
@@ -3,81 +3,100 @@ package ui
 import (
 	"fmt"
 
+	"github.com/biferdou/illapaca/api"
 	"github.com/biferdou/illapaca/model"
-	"github.com/fatih/color"
 )
 
 // DisplayCurrentWeather outputs current weather conditions with a clean design
-func DisplayCurrentWeather(data *model.WeatherData) {
-	fmt.Println()
+func (r *Renderer) DisplayCurrentWeather(data *model.WeatherData) {
+	fmt.Fprintln(r.w)
 
 	// Location and current time with clean styling
-	locationTitle := color.New(color.FgHiCyan, color.Bold)
-	locationTitle.Printf("📍 %s, %s\n", data.Location.Name, data.Location.Country)
-	fmt.Printf("🕒 Local time: %s\n", data.Location.Localtime)
-	fmt.Println()
+	locationTitle := r.paint(RoleHeading)
+	locationTitle.Fprintf(r.w, "%s%s, %s\n", r.mark("📍 "), data.Location.Name, data.Location.Country)
+	fmt.Fprintf(r.w, "%s%s\n", r.mark("🕒 "), r.tf("Local time: %s", r.dateTime(data.Location.Localtime)))
+	fmt.Fprintln(r.w)
 
 	// Current conditions with clean styling
-	conditionIcon := GetConditionIcon(data.Current.Condition.Text)
-
-	current := color.New(color.FgHiWhite, color.Bold)
-	current.Println("Current Weather")
-	fmt.Println()
-
-	tempC := color.New(color.FgHiYellow, color.Bold)
-	tempF := color.New(color.FgYellow)
-	condition := color.New(color.FgHiWhite)
-
-	condition.Printf("%s  %s ", conditionIcon, data.Current.Condition.Text)
-	tempC.Printf("%.1f°C", data.Current.TempC)
-	fmt.Printf(" / ")
-	tempF.Printf("%.1f°F", data.Current.TempF)
-	fmt.Println()
-
-	feelsLike := color.New(color.FgHiWhite)
-	feelsLike.Printf("Feels like: ")
-	tempC.Printf("%.1f°C", data.Current.FeelsLikeC)
-	fmt.Printf(" / ")
-	tempF.Printf("%.1f°F", data.Current.FeelsLikeF)
-	fmt.Println()
-	fmt.Println()
-
-	// Create styled labels for details
-	labelStyle := color.New(color.FgHiBlue)
-	valueStyle := color.New(color.FgWhite)
+	isDay := data.Current.IsDay == 1
+	conditionIcon := r.icon(data.Current.Condition, isDay)
+
+	current := r.paint(RoleSubheading)
+	current.Fprintln(r.w, r.t("Current Weather"))
+	fmt.Fprintln(r.w)
+
+	if art := r.icons().Art(data.Current.Condition, isDay); art != nil && !r.settings.Accessible {
+		artStyle := r.paint(RoleAccent)
+		for _, line := range art {
+			artStyle.Fprintln(r.w, line)
+		}
+		fmt.Fprintln(r.w)
+	}
+
+	tempC := r.paint(RoleTemp)
+	tempF := r.paint(RoleTempAlt)
+	condition := r.paint(RoleText)
+
+	if r.settings.Accessible {
+		condition.Fprintln(r.w, r.tf("Condition: %s", conditionIcon))
+		condition.Fprint(r.w, r.t("Temperature: "))
+	} else {
+		condition.Fprintf(r.w, "%s  %s ", conditionIcon, r.conditionText(data.Current.Condition))
+	}
+	tempC.Fprint(r.w, r.tf("%.1f°C", data.Current.TempC))
+	fmt.Fprintf(r.w, " / ")
+	tempF.Fprint(r.w, r.tf("%.1f°F", data.Current.TempF))
+	fmt.Fprintln(r.w)
+
+	feelsLike := r.paint(RoleText)
+	feelsLike.Fprint(r.w, r.t("Feels like: "))
+	tempC.Fprint(r.w, r.tf("%.1f°C", data.Current.FeelsLikeC))
+	fmt.Fprintf(r.w, " / ")
+	tempF.Fprint(r.w, r.tf("%.1f°F", data.Current.FeelsLikeF))
+	fmt.Fprintln(r.w)
+	r.displayClimateContext(data)
+	fmt.Fprintln(r.w)
+
+	// Create styled labels for details, aligned whatever their language
+	labelStyle := r.paint(RoleLabel)
+	valueStyle := r.paint(RoleValue)
+	labels := []string{r.t("Wind:"), r.t("Humidity:"), r.t("Precip:"), r.t("Visibility:"), r.t("UV Index:")}
+	width := labelWidth(labels...)
 
 	// Wind info
-	labelStyle.Printf("Wind:      ")
-	valueStyle.Printf("%.1f km/h %s\n", data.Current.WindKph, data.Current.WindDir)
+	labelStyle.Fprintf(r.w, "%-*s", width, labels[0])
+	valueStyle.Fprintln(r.w, r.tf("%.1f km/h %s", data.Current.WindKph, r.compass(data.Current.WindDir)))
 
 	// Humidity
-	labelStyle.Printf("Humidity:  ")
-	valueStyle.Printf("%d%%\n", data.Current.Humidity)
+	labelStyle.Fprintf(r.w, "%-*s", width, labels[1])
+	valueStyle.Fprintf(r.w, "%d%%\n", data.Current.Humidity)
 
 	// Precipitation
-	labelStyle.Printf("Precip:    ")
-	valueStyle.Printf("%.1f mm\n", data.Current.PrecipMm)
+	labelStyle.Fprintf(r.w, "%-*s", width, labels[2])
+	valueStyle.Fprintln(r.w, r.tf("%.1f mm", data.Current.PrecipMm))
 
 	// Visibility
-	labelStyle.Printf("Visibility:")
-	valueStyle.Printf(" %.1f km\n", data.Current.VisKm)
+	labelStyle.Fprintf(r.w, "%-*s", width, labels[3])
+	valueStyle.Fprintln(r.w, r.tf("%.1f km", data.Current.VisKm))
 
 	// UV Index with color coding based on value
-	labelStyle.Printf("UV Index:  ")
-
-	// Color-code UV index based on intensity
-	uvStyle := color.New(color.FgHiGreen)
-	if data.Current.UV > 3 && data.Current.UV <= 6 {
-		uvStyle = color.New(color.FgHiYellow)
-	} else if data.Current.UV > 6 && data.Current.UV <= 8 {
-		uvStyle = color.New(color.FgHiMagenta)
-	} else if data.Current.UV > 8 {
-		uvStyle = color.New(color.FgHiRed)
+	labelStyle.Fprintf(r.w, "%-*s", width, labels[4])
+	if api.SuppliesUV(data.Provider) {
+		// Color-code UV index based on intensity
+		uvStyle := r.paint(RoleOK)
+		if data.Current.UV > 3 && data.Current.UV <= 6 {
+			uvStyle = r.paint(RoleCaution)
+		} else if data.Current.UV > 6 && data.Current.UV <= 8 {
+			uvStyle = r.paint(RoleWarning)
+		} else if data.Current.UV > 8 {
+			uvStyle = r.paint(RoleDanger)
+		}
+		uvStyle.Fprintln(r.w, r.tf("%.1f", data.Current.UV))
+	} else {
+		r.paint(RoleMuted).Fprintln(r.w, r.tf("UV index not available from %s", data.Provider))
 	}
-
-	uvStyle.Printf("%.1f\n", data.Current.UV)
-	fmt.Println()
+	fmt.Fprintln(r.w)
 
 	// Check alerts
-	CheckAlerts(data)
+	r.CheckAlerts(data)
 }
@@ -2,34 +2,34 @@ package ui
 
 import (
 	"fmt"
+	"unicode/utf8"
 
-	"github.com/biferdou/illapaca/config"
 	"github.com/biferdou/illapaca/model"
-	"github.com/fatih/color"
 )
 
 // DisplayDashboard displays the full dashboard
-func DisplayDashboard(data *model.WeatherData) {
+func (r *Renderer) DisplayDashboard(data *model.WeatherData) {
 	// Title banner
-	displayDashboardHeader()
+	r.displayDashboardHeader()
 
 	// Display components in sequence
-	DisplayCurrentWeather(data)
-	DisplayForecast(data)
-	DisplayTemperatureChart(data)
+	r.DisplayCurrentWeather(data)
+	r.DisplayAdvice(data)
+	r.DisplayForecast(data)
+	r.DisplayTemperatureChart(data)
 
 	// If at least one day of forecast available, show precipitation chart
 	if len(data.Forecast.ForecastDay) > 0 {
-		DisplayPrecipitationChart(data.Forecast.ForecastDay[0])
+		r.DisplayPrecipitationChart(data.Forecast.ForecastDay[0])
 	}
 }
 
 // displayDashboardHeader displays the dashboard title banner
-func displayDashboardHeader() {
-	title := color.New(color.FgHiCyan, color.Bold)
-	title.Println("ILLAPA WEATHER DASHBOARD")
-	fmt.Println(dash(38))
-	fmt.Println()
+func (r *Renderer) displayDashboardHeader() {
+	title := r.paint(RoleHeading)
+	title.Fprintln(r.w, r.t("ILLAPA WEATHER DASHBOARD"))
+	r.rule(38)
+	fmt.Fprintln(r.w)
 }
 
 // Helper function to create a horizontal line
@@ -41,65 +41,113 @@ func dash(length int) string {
 	return dashes
 }
 
+// rule draws a horizontal line under a title, except in accessible mode
+func (r *Renderer) rule(length int) {
+	if !r.settings.Accessible {
+		fmt.Fprintln(r.w, dash(length))
+	}
+}
+
 // DisplayExtendedDashboard shows a more detailed dashboard with hourly forecasts
-func DisplayExtendedDashboard(data *model.WeatherData, showHourly bool) {
-	displayDashboardHeader()
+func (r *Renderer) DisplayExtendedDashboard(data *model.WeatherData, showHourly bool) {
+	r.displayDashboardHeader()
 
-	DisplayCurrentWeather(data)
-	DisplayForecast(data)
-	DisplayTemperatureChart(data)
+	r.DisplayCurrentWeather(data)
+	r.DisplayAdvice(data)
+	r.DisplayForecast(data)
+	r.DisplayTemperatureChart(data)
 
 	if len(data.Forecast.ForecastDay) > 0 {
 		// Show today's precipitation chart
-		DisplayPrecipitationChart(data.Forecast.ForecastDay[0])
+		r.DisplayPrecipitationChart(data.Forecast.ForecastDay[0])
 
 		// Show hourly forecast if requested
 		if showHourly {
-			DisplayHourlyForecast(data.Forecast.ForecastDay[0])
+			r.DisplayHourlyForecast(data.Forecast.ForecastDay[0])
 		}
 	}
 }
 
+// displayCompactTrends writes sparklines of temperature and rain chance
+// over the coming hours, as wide as the remaining columns allow
+func (r *Renderer) displayCompactTrends(hours []model.Hour, sep string) {
+	if len(hours) == 0 {
+		return
+	}
+	if r.settings.Accessible {
+		fmt.Fprintln(r.w, r.tf("Next 24 hours: temperature %s, rain %s", r.tempTrend(hours, 0), r.rainTrend(hours, 0)))
+		return
+	}
+
+	label, temp, rain := r.t("Next 24h:"), r.t("Temp"), r.t("Rain")
+	width := r.sparkWidth(utf8.RuneCountInString(label+temp+rain+sep)+3, 2)
+	if width == 0 {
+		return
+	}
+	fmt.Fprintf(r.w, "%s %s %s%s%s %s\n", label, temp, r.tempTrend(hours, width), sep, rain, r.rainTrend(hours, width))
+}
+
 // DisplayCompactDashboard shows a minimal dashboard for small terminals
-func DisplayCompactDashboard(data *model.WeatherData) {
-	compactTitle := color.New(color.FgHiCyan, color.Bold)
-	compactTitle.Println("ILLAPA WEATHER")
-	fmt.Println(dash(20))
+func (r *Renderer) DisplayCompactDashboard(data *model.WeatherData) {
+	compactTitle := r.paint(RoleHeading)
+	compactTitle.Fprintln(r.w, r.t("ILLAPA WEATHER"))
+	r.rule(20)
+
+	// Separators are read aloud by screen readers, so accessible mode
+	// uses plain punctuation
+	sep := " | "
+	if r.settings.Accessible {
+		sep = ", "
+	}
 
 	// Simplified current weather display
-	locationTitle := color.New(color.FgHiCyan)
-	locationTitle.Printf("📍 %s, %s | %s\n",
-		data.Location.Name, data.Location.Country, data.Location.Localtime)
+	locationTitle := r.paint(RoleAccent)
+	locationTitle.Fprintf(r.w, "%s%s, %s%s%s\n", r.mark("📍 "),
+		data.Location.Name, data.Location.Country, sep, r.dateTime(data.Location.Localtime))
 
 	// Current conditions - compact format
-	conditionIcon := GetConditionIcon(data.Current.Condition.Text)
+	conditionIcon := r.icon(data.Current.Condition, data.Current.IsDay == 1)
 
-	tempC := color.New(color.FgHiYellow, color.Bold)
-	fmt.Printf("%s %s ", conditionIcon, data.Current.Condition.Text)
-	tempC.Printf("%.1f°C", data.Current.TempC)
-	fmt.Printf(" (Feels: %.1f°C) | ", data.Current.FeelsLikeC)
-	fmt.Printf("Wind: %.1f km/h %s | Hum: %d%%\n\n",
-		data.Current.WindKph, data.Current.WindDir, data.Current.Humidity)
+	tempC := r.paint(RoleTemp)
+	if r.settings.Accessible {
+		fmt.Fprintf(r.w, "%s, ", conditionIcon)
+	} else {
+		fmt.Fprintf(r.w, "%s %s ", conditionIcon, r.conditionText(data.Current.Condition))
+	}
+	tempC.Fprint(r.w, r.tf("%.1f°C", data.Current.TempC))
+	fmt.Fprint(r.w, " "+r.tf("(Feels: %.1f°C)", data.Current.FeelsLikeC)+sep)
+	fmt.Fprint(r.w, r.tf("Wind: %.1f km/h %s", data.Current.WindKph, r.compass(data.Current.WindDir))+sep)
+	fmt.Fprintf(r.w, "%s\n", r.tf("Hum: %d%%", data.Current.Humidity))
+	r.displayCompactTrends(upcomingHours(data, 24), sep)
+	fmt.Fprintln(r.w)
 
 	// Compact forecast
-	forecastTitle := color.New(color.FgHiMagenta, color.Bold)
-	forecastTitle.Println("3-Day Forecast:")
+	forecastTitle := r.paint(RoleTitle)
+	forecastTitle.Fprintln(r.w, r.t("3-Day Forecast:"))
 
 	days := min(len(data.Forecast.ForecastDay), 3)
+	if days == 0 {
+		fmt.Fprintln(r.w, r.t("No forecast data available"))
+	}
 
 	for i := range days {
 		day := data.Forecast.ForecastDay[i]
-		icon := GetConditionIcon(day.Day.Condition.Text)
-		fmt.Printf("%s: %s %.1f°C/%.1f°C | Rain: %d%%\n",
-			day.Date, icon, day.Day.MaxTempC, day.Day.MinTempC, day.Day.DailyChanceOfRain)
+		icon := r.icon(day.Day.Condition, true)
+		if r.settings.Accessible {
+			fmt.Fprintln(r.w, r.tf("%s: %s, high %.1f°C, low %.1f°C, rain %d%%",
+				r.date(day.Date), icon, day.Day.MaxTempC, day.Day.MinTempC, day.Day.DailyChanceOfRain))
+			continue
+		}
+		fmt.Fprintln(r.w, r.tf("%s: %s %.1f°C/%.1f°C | Rain: %d%%",
+			r.date(day.Date), icon, day.Day.MaxTempC, day.Day.MinTempC, day.Day.DailyChanceOfRain))
 	}
 
-	fmt.Println()
+	fmt.Fprintln(r.w)
 
 	// Check alerts but only show count
-	alerts := getAlerts(data, config.AppConfig.AlertThresholds)
+	alerts := Alerts(data, r.settings.AlertThresholds)
 	if len(alerts) > 0 {
-		alertMsg := color.New(color.FgHiRed, color.Bold)
-		alertMsg.Printf("⚠️ %d weather alerts detected\n\n", len(alerts))
+		alertMsg := r.paint(RoleAlert)
+		alertMsg.Fprintf(r.w, "%s%s\n\n", r.mark("⚠️ "), r.tf("%d weather alerts detected", len(alerts)))
 	}
 }
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/biferdou/illapaca/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// DisplayFavorites shows one row per favorite location with its current
+// conditions, today's range and trends over the next 24 hours
+func (r *Renderer) DisplayFavorites(locations []*model.WeatherData) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.t("Favorite Locations"))
+	fmt.Fprintln(r.w)
+
+	if len(locations) == 0 {
+		r.placeholder("No favorite locations saved")
+		return
+	}
+
+	// The trends share what the other columns leave: the longest name and
+	// about 40 columns of readings, padding and separators
+	nameWidth := utf8.RuneCountInString(r.t("Location"))
+	for _, data := range locations {
+		nameWidth = max(nameWidth, utf8.RuneCountInString(data.Location.Name))
+	}
+	width := r.sparkWidth(nameWidth+40, 2)
+
+	table := r.newTable([]string{r.t("Location"), r.t("Now"), r.t("High/Low"), r.t("Temp (24h)"), r.t("Rain (24h)")})
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT,
+		tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT})
+	if r.colorEnabled() {
+		table.SetHeaderColor(
+			r.headerColors(RoleHeader),
+			r.headerColors(RoleHeader),
+			r.headerColors(RoleHeader),
+			r.headerColors(RoleHeader),
+			r.headerColors(RoleHeader),
+		)
+	}
+
+	for _, data := range locations {
+		now := r.icon(data.Current.Condition, data.Current.IsDay == 1) + " " + r.tf("%.0f°C", data.Current.TempC)
+		if r.settings.Accessible {
+			now = r.icon(data.Current.Condition, data.Current.IsDay == 1) + ", " + r.tf("%.0f°C", data.Current.TempC)
+		}
+		highLow := "--"
+		if len(data.Forecast.ForecastDay) > 0 {
+			day := data.Forecast.ForecastDay[0].Day
+			highLow = r.tf("%.0f°C/%.0f°C", day.MaxTempC, day.MinTempC)
+		}
+		hours := upcomingHours(data, 24)
+		table.Append([]string{
+			data.Location.Name,
+			now,
+			highLow,
+			OrPlaceholder(r.tempTrend(hours, width)),
+			OrPlaceholder(r.rainTrend(hours, width)),
+		})
+	}
+	table.Render()
+	fmt.Fprintln(r.w)
+}
@@ -2,107 +2,115 @@ package ui
 
 import (
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/biferdou/illapaca/model"
-	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 )
 
 // DisplayForecast outputs weather forecast with clean styling
-func DisplayForecast(data *model.WeatherData) {
-	forecastTitle := color.New(color.FgHiMagenta, color.Bold)
-	forecastTitle.Println("Weather Forecast")
-	fmt.Println()
+func (r *Renderer) DisplayForecast(data *model.WeatherData) {
+	forecastTitle := r.paint(RoleTitle)
+	forecastTitle.Fprintln(r.w, r.t("Weather Forecast"))
+	fmt.Fprintln(r.w)
+
+	if len(data.Forecast.ForecastDay) == 0 {
+		r.placeholder("No forecast data available")
+		return
+	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Date", "Condition", "Max", "Min", "Rain", "Sunrise", "Sunset"})
+	header := []string{r.t("Date"), r.t("Condition"), r.t("Max"), r.t("Min"), r.t("Rain"), r.t("Sunrise"), r.t("Sunset")}
 	// Ensure the table has a consistent width by setting column alignments
-	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT,
+	alignment := []int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT,
 		tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT,
-		tablewriter.ALIGN_CENTER, tablewriter.ALIGN_CENTER})
-	table.SetBorder(false)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("  ") // Use double spaces instead of pipes
-	table.SetRowSeparator("")
-	table.SetHeaderColor(
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiBlueColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiBlueColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiRedColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiCyanColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiBlueColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiYellowColor},
-		tablewriter.Colors{tablewriter.Bold, tablewriter.FgHiMagentaColor},
-	)
+		tablewriter.ALIGN_CENTER, tablewriter.ALIGN_CENTER}
+	headerColors := []tablewriter.Colors{
+		r.headerColors(RoleHeader),
+		r.headerColors(RoleHeader),
+		r.headerColors(RoleHot),
+		r.headerColors(RoleCold),
+		r.headerColors(RoleHeader),
+		r.headerColors(RoleHeader),
+		r.headerColors(RoleHeader),
+	}
+	if r.climate != nil {
+		header = append(header, r.t("vs Normal"))
+		alignment = append(alignment, tablewriter.ALIGN_LEFT)
+		headerColors = append(headerColors, r.headerColors(RoleHeader))
+	}
+	table := r.newTable(header)
+	table.SetColumnAlignment(alignment)
+	if r.colorEnabled() {
+		table.SetHeaderColor(headerColors...)
+	}
 
 	for _, day := range data.Forecast.ForecastDay {
-		condition := day.Day.Condition.Text
-		icon := GetConditionIcon(condition)
+		icon := r.icon(day.Day.Condition, true)
 
 		// Use just the icon for the condition to save space and maintain alignment
 		conditionWithIcon := icon
 
-		maxTemp := fmt.Sprintf("%.1f°C", day.Day.MaxTempC)
-		minTemp := fmt.Sprintf("%.1f°C", day.Day.MinTempC)
+		maxTemp := r.tf("%.1f°C", day.Day.MaxTempC)
+		minTemp := r.tf("%.1f°C", day.Day.MinTempC)
 
 		// Style rain chance based on probability
-		var rainChance string
 		rainProb := day.Day.DailyChanceOfRain
-		if rainProb < 20 {
-			rainChance = fmt.Sprintf("\x1b[38;5;39m%d%%\x1b[0m", rainProb)
-		} else if rainProb < 40 {
-			rainChance = fmt.Sprintf("\x1b[38;5;45m%d%%\x1b[0m", rainProb)
-		} else if rainProb < 60 {
-			rainChance = fmt.Sprintf("\x1b[38;5;51m%d%%\x1b[0m", rainProb)
-		} else if rainProb < 80 {
-			rainChance = fmt.Sprintf("\x1b[38;5;33m%d%%\x1b[0m", rainProb)
-		} else {
-			rainChance = fmt.Sprintf("\x1b[38;5;27m%d%%\x1b[0m", rainProb)
-		}
+		rainChance := r.paint(scaleRole(rainRoles, float64(rainProb), 0, 100)).Sprintf("%d%%", rainProb)
 
-		table.Append([]string{
-			day.Date,
+		row := []string{
+			r.date(day.Date),
 			conditionWithIcon,
 			maxTemp,
 			minTemp,
 			rainChance,
-			day.Astro.Sunrise,
-			day.Astro.Sunset,
-		})
+			r.clock(OrPlaceholder(day.Astro.Sunrise)),
+			r.clock(OrPlaceholder(day.Astro.Sunset)),
+		}
+		if r.climate != nil {
+			row = append(row, r.forecastNormal(day))
+		}
+		table.Append(row)
 	}
 
 	table.Render()
-	fmt.Println()
+	fmt.Fprintln(r.w)
 }
 
 // DisplayHourlyForecast outputs hourly weather forecast for a given day
-func DisplayHourlyForecast(day model.ForecastDay) {
-	hourlyTitle := color.New(color.FgHiCyan, color.Bold)
-	hourlyTitle.Printf("Hourly Forecast for %s\n", day.Date)
-	fmt.Println()
+func (r *Renderer) DisplayHourlyForecast(day model.ForecastDay) {
+	hourlyTitle := r.paint(RoleHeading)
+	hourlyTitle.Fprintln(r.w, r.tf("Hourly Forecast for %s", r.date(day.Date)))
+	fmt.Fprintln(r.w)
+
+	if len(day.Hour) == 0 {
+		r.placeholder("No hourly data available")
+		return
+	}
 
 	// Create a lookup table for condition descriptions
+	// Icons are kept in order of first appearance so output is stable
 	conditionDescriptions := make(map[string]string)
+	var icons []string
 	for i, hour := range day.Hour {
 		if i%3 == 0 { // Only include every 3 hours to save space
-			icon := GetConditionIcon(hour.Condition.Text)
-			conditionDescriptions[icon] = hour.Condition.Text
+			icon := r.icon(hour.Condition, hour.IsDay == 1)
+			if _, seen := conditionDescriptions[icon]; !seen {
+				icons = append(icons, icon)
+			}
+			conditionDescriptions[icon] = r.conditionText(hour.Condition)
 		}
 	}
 
-	// Display condition key first
-	fmt.Println("Weather conditions:")
-	for icon, description := range conditionDescriptions {
-		fmt.Printf("%s %s\n", icon, description)
+	// Display condition key first, unless conditions are already spelled out
+	if !r.settings.Accessible {
+		fmt.Fprintln(r.w, r.t("Weather conditions:"))
+		for _, icon := range icons {
+			fmt.Fprintf(r.w, "%s %s\n", icon, conditionDescriptions[icon])
+		}
+		fmt.Fprintln(r.w)
 	}
-	fmt.Println()
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Time", "Temp", "Condition", "Rain Chance"})
-	table.SetBorder(false)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("  ") // Use double spaces instead of pipes
-	table.SetRowSeparator("")
+	table := r.newTable([]string{r.t("Time"), r.t("Temp"), r.t("Condition"), r.t("Rain Chance")})
 
 	// Display only a subset of hours to keep the output manageable
 	for i, hour := range day.Hour {
@@ -111,11 +119,14 @@ func DisplayHourlyForecast(day model.ForecastDay) {
 		}
 
 		// Extract just the time portion (15:04)
-		timeOnly := hour.Time[11:16]
+		timeOnly := "--:--"
+		if t, err := time.Parse("2006-01-02 15:04", hour.Time); err == nil {
+			timeOnly = t.Format("15:04")
+		}
 
-		temp := fmt.Sprintf("%.1f°C", hour.TempC)
+		temp := r.tf("%.1f°C", hour.TempC)
 		// Use just the icon for display, not the full condition text
-		condition := GetConditionIcon(hour.Condition.Text)
+		condition := r.icon(hour.Condition, hour.IsDay == 1)
 		rainChance := fmt.Sprintf("%d%%", hour.ChanceOfRain)
 
 		table.Append([]string{
@@ -127,5 +138,5 @@ func DisplayHourlyForecast(day model.ForecastDay) {
 	}
 
 	table.Render()
-	fmt.Println()
+	fmt.Fprintln(r.w)
 }
@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/biferdou/illapaca/activity"
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
+)
+
+// randomFloat returns a plausible reading, occasionally an extreme or
+// non-finite one
+func randomFloat(rng *rand.Rand) float64 {
+	switch rng.Intn(20) {
+	case 0:
+		return math.NaN()
+	case 1:
+		return math.Inf(1 - 2*rng.Intn(2))
+	case 2:
+		return 0
+	case 3:
+		return (rng.Float64() - 0.5) * 1e6
+	default:
+		return rng.Float64()*80 - 30
+	}
+}
+
+// randomTime returns an hourly timestamp, occasionally malformed
+func randomTime(rng *rand.Rand, hour int) string {
+	switch rng.Intn(10) {
+	case 0:
+		return ""
+	case 1:
+		return "12:00"
+	default:
+		return time.Date(2025, 10, 17, hour, 0, 0, 0, time.UTC).Format("2006-01-02 15:04")
+	}
+}
+
+// randomWeather builds weather data with a random number of days and
+// hours, optionally with every temperature equal
+func randomWeather(seed int64, days, hours uint8, flat bool) *model.WeatherData {
+	rng := rand.New(rand.NewSource(seed))
+	temp := func() float64 {
+		if flat {
+			return 12
+		}
+		return randomFloat(rng)
+	}
+
+	data := &model.WeatherData{
+		Provider: []string{"", api.ProviderWeatherAPI, api.ProviderOpenWeatherMap}[rng.Intn(3)],
+		Current: model.CurrentWeather{
+			TempC:      temp(),
+			Condition:  model.Condition{Text: []string{"", "Sunny", "Cloudy", "Heavy rain", "???"}[rng.Intn(5)]},
+			WindKph:    randomFloat(rng),
+			Humidity:   rng.Intn(300) - 100,
+			FeelsLikeC: temp(),
+			UV:         randomFloat(rng),
+		},
+	}
+
+	for d := range int(days % 16) {
+		day := model.ForecastDay{
+			Date: time.Date(2025, 10, 17+d, 0, 0, 0, 0, time.UTC).Format("2006-01-02"),
+			Day: model.Day{
+				MaxTempC:          temp(),
+				MinTempC:          temp(),
+				DailyChanceOfRain: rng.Intn(300) - 100,
+			},
+		}
+		if rng.Intn(2) == 0 {
+			day.Astro = model.Astro{Sunrise: "06:45 AM", Sunset: "06:10 PM"}
+		}
+		for h := range int(hours % 49) {
+			day.Hour = append(day.Hour, model.Hour{
+				Time:         randomTime(rng, h%24),
+				TempC:        temp(),
+				ChanceOfRain: rng.Intn(300) - 100,
+				WindKph:      randomFloat(rng),
+				GustKph:      randomFloat(rng),
+				WindDegree:   rng.Intn(1000) - 300,
+				UV:           randomFloat(rng),
+				FeelsLikeC:   temp(),
+				IsDay:        rng.Intn(2),
+			})
+		}
+		data.Forecast.ForecastDay = append(data.Forecast.ForecastDay, day)
+	}
+	return data
+}
+
+// fuzzClimate derives climate statistics for the forecast days from their
+// own readings, so the comparisons see the same extremes
+func fuzzClimate(data *model.WeatherData) *model.Climate {
+	c := &model.Climate{Location: data.Location.Name, Days: map[string]model.ClimateDay{}}
+	for _, day := range data.Forecast.ForecastDay {
+		if len(day.Date) < 10 {
+			continue
+		}
+		c.Days[day.Date[5:]] = model.ClimateDay{
+			MonthDay:       day.Date[5:],
+			Samples:        len(day.Hour),
+			NormalTempC:    data.Current.FeelsLikeC,
+			NormalMaxC:     day.Day.MinTempC,
+			NormalMinC:     day.Day.MaxTempC,
+			P10TempC:       day.Day.MaxTempC,
+			P50TempC:       data.Current.TempC,
+			P90TempC:       day.Day.MinTempC,
+			RecordHighC:    day.Day.MinTempC,
+			RecordHighDate: day.Date,
+			RecordLowC:     day.Day.MaxTempC,
+			RecordLowDate:  day.Date[:4],
+		}
+	}
+	return c
+}
+
+// renderAll runs every renderer over data
+func renderAll(w io.Writer, data *model.WeatherData) {
+	r := NewRenderer(w, Settings{NoColor: true})
+	climate := fuzzClimate(data)
+	r.DisplayCurrentWeather(data)
+	r.DisplayForecast(data)
+	r.DisplayTemperatureChart(data)
+	r.DisplayDashboard(data)
+	r.DisplayExtendedDashboard(data, true)
+	r.DisplayCompactDashboard(data)
+	r.DisplayLocationComparison(data, data)
+	// The sparklines share whatever width is left
+	for _, width := range []int{1, 50, 300} {
+		sized := NewRenderer(w, Settings{NoColor: true, Width: width})
+		sized.DisplayCompactDashboard(data)
+		sized.DisplayLocationComparison(data, data)
+		sized.DisplayFavorites([]*model.WeatherData{data, data})
+	}
+	r.DisplayWind(data)
+	for _, name := range HeatmapMetricNames() {
+		metric, err := LookupHeatmapMetric(name)
+		if err != nil {
+			panic(err)
+		}
+		r.DisplayHeatmap(data, metric)
+	}
+	accessible := NewRenderer(w, Settings{NoColor: true, Accessible: true})
+	r.DisplayAdvice(data)
+	accessible.DisplayAdvice(data)
+	for _, c := range []Commute{
+		{Depart: 8 * time.Hour, Return: 17*time.Hour + 30*time.Minute, Travel: 45 * time.Minute},
+		{},
+		{Depart: 23 * time.Hour, Return: time.Hour, Travel: 5 * time.Hour},
+	} {
+		r.DisplayCommute(data, data, c)
+		r.DisplayCommute(data, &model.WeatherData{}, c)
+		accessible.DisplayCommute(data, data, c)
+	}
+	for _, name := range activity.Names() {
+		profile, err := activity.Lookup(name)
+		if err != nil {
+			panic(err)
+		}
+		r.DisplayPlan(data, profile, activity.DefaultMinScore)
+		accessible.DisplayPlan(data, profile, 0)
+	}
+	for _, day := range data.Forecast.ForecastDay {
+		r.DisplayPrecipitationChart(day)
+		r.DisplayHourlyForecast(day)
+		r.DisplayClimate(climate, day.Date)
+	}
+	r.DisplayClimate(climate, "")
+
+	// Again with the comparison with normal turned on
+	r.SetClimate(climate)
+	r.DisplayCurrentWeather(data)
+	r.DisplayForecast(data)
+	r.DisplayExtendedDashboard(data, true)
+
+	prompt, err := r.ParsePromptFormat("{{.Icon}} {{.Temp}} {{.FeelsLike}} {{.Condition}} {{.RainChance}}% {{range .Alerts}}{{.}}{{end}}")
+	if err != nil {
+		panic(err)
+	}
+	for _, preset := range PromptPresets {
+		r.DisplayPrompt(data, prompt, preset)
+		r.DisplayPrompt(nil, prompt, preset)
+	}
+
+	tmpl, err := template.New("fuzz").Funcs(r.Funcs()).Parse(fuzzTemplate)
+	if err != nil {
+		panic(err)
+	}
+	if err := r.DisplayTemplate(tmpl, data); err != nil {
+		panic(err)
+	}
+}
+
+// fuzzTemplate calls every template helper on the weather data
+const fuzzTemplate = `{{icon .Current}} {{temp .Current.TempC}} {{speed .Current.WindKph}}
+{{c2f .Current.TempC}} {{f2c .Current.TempF}} {{kph2mph .Current.WindKph}} {{mm2in .Current.PrecipMm}}
+{{round .Current.FeelsLikeC 1}} {{color "alert" .Current.Condition.Text}} {{color "hi-red bold" "x"}}
+{{sparkline (maxTemps .)}} {{sparkline (minTemps .)}}
+{{bar .Current.Humidity 100 10}} {{bar .Current.UV 11 10}} {{bar .Current.TempC .Current.FeelsLikeC 8}}
+{{range .Forecast.ForecastDay}}{{icon .Day.Condition}} {{sparkline (hourlyTemps .)}} {{sparkline (hourlyRain .)}}
+{{range .Hour}}{{icon .}}{{end}}
+{{end}}{{upper (join (alerts .) "; ")}} {{lower .Location.Name}}
+`
+
+func FuzzRenderers(f *testing.F) {
+	f.Add(int64(1), uint8(0), uint8(0), false)
+	f.Add(int64(2), uint8(1), uint8(1), false)
+	f.Add(int64(3), uint8(3), uint8(24), false)
+	f.Add(int64(4), uint8(3), uint8(24), true)
+	f.Add(int64(5), uint8(2), uint8(5), false)
+
+	f.Fuzz(func(t *testing.T, seed int64, days, hours uint8, flat bool) {
+		renderAll(io.Discard, randomWeather(seed, days, hours, flat))
+	})
+}
+
+func TestEmptyForecastPlaceholders(t *testing.T) {
+	var buf bytes.Buffer
+	renderAll(&buf, &model.WeatherData{})
+
+	if !strings.Contains(buf.String(), "No forecast data available") {
+		t.Errorf("missing placeholder in output:\n%s", buf.String())
+	}
+}
+
+func TestGustBarsClamped(t *testing.T) {
+	hours := []model.Hour{
+		{Time: "2025-10-17 00:00", WindKph: 20, GustKph: 5},
+		{Time: "2025-10-17 03:00", WindKph: -1e6, GustKph: math.NaN()},
+		{Time: "2025-10-17 06:00", WindKph: math.Inf(1), GustKph: 30},
+	}
+	var buf bytes.Buffer
+	NewRenderer(&buf, Settings{NoColor: true}).displayGustBars(append(hours, make([]model.Hour, 6)...))
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if n := utf8.RuneCountInString(line); n > 80 {
+			t.Errorf("line of %d characters: %q", n, line)
+		}
+	}
+}
+
+func TestFlatTemperatureColor(t *testing.T) {
+	r := NewRenderer(io.Discard, Settings{NoColor: true})
+	if r.getTemperatureColor(10, 10, 10) == nil {
+		t.Error("no color for a flat range")
+	}
+}
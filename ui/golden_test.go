@@ -0,0 +1,306 @@
+package ui_test
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/biferdou/illapaca/activity"
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/i18n"
+	"github.com/biferdou/illapaca/model"
+	"github.com/biferdou/illapaca/ui"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+var testSettings = ui.Settings{
+	Units: "metric",
+	AlertThresholds: model.AlertThresholds{
+		HighTemp:      35,
+		LowTemp:       0,
+		Precipitation: 70,
+		WindSpeed:     30,
+	},
+	NoColor: true,
+}
+
+// fetch loads a fixture forecast through the named provider
+func fetch(t *testing.T, provider, location string, days int) *model.WeatherData {
+	t.Helper()
+	server := apitest.NewServer()
+	defer server.Close()
+
+	p, err := api.NewProvider(provider, apitest.APIKey, server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := p.FetchWeather(context.Background(), location, days)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// heatmapMetric looks up a heatmap metric by name
+func heatmapMetric(t *testing.T, name string) *ui.HeatmapMetric {
+	t.Helper()
+	metric, err := ui.LookupHeatmapMetric(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metric
+}
+
+// commute leaves at 08:00 and returns at 17:30, 45 minutes each way
+var commute = ui.Commute{Depart: 8 * time.Hour, Return: 17*time.Hour + 30*time.Minute, Travel: 45 * time.Minute}
+
+// earlyCommute fetches the forecast as of early on Friday, with a wet
+// morning commute and a windy evening one
+func earlyCommute(t *testing.T) *model.WeatherData {
+	t.Helper()
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+	data.Location.Localtime = "2025-10-17 06:00"
+	for i := range data.Forecast.ForecastDay[0].Hour {
+		hour := &data.Forecast.ForecastDay[0].Hour[i]
+		switch hour.Time {
+		case "2025-10-17 08:00":
+			hour.ChanceOfRain = 80
+		case "2025-10-17 18:00":
+			hour.WindKph = 42
+		}
+	}
+	return data
+}
+
+// plannedActivity looks up a built-in activity by name
+func plannedActivity(t *testing.T, name string) *activity.Profile {
+	t.Helper()
+	profile, err := activity.Lookup(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return profile
+}
+
+// assertGolden compares got with testdata/<name>.golden
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./ui -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run go test ./ui -update to accept)\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+	owm := fetch(t, api.ProviderOpenWeatherMap, apitest.LocationOK, 3)
+	empty := fetch(t, api.ProviderWeatherAPI, apitest.LocationEmpty, 3)
+
+	// Muggy heat and a windy frost, for the advice panel
+	hot, cold := *data, *data
+	hot.Current.TempC, hot.Current.FeelsLikeC, hot.Current.Humidity, hot.Current.UV = 33, 40, 65, 9
+	cold.Current.TempC, cold.Current.FeelsLikeC, cold.Current.WindKph, cold.Current.PrecipMm = -6, -13, 35, 0.4
+
+	early := earlyCommute(t)
+
+	tests := []struct {
+		name   string
+		render func(r *ui.Renderer)
+	}{
+		{"current", func(r *ui.Renderer) { r.DisplayCurrentWeather(data) }},
+		{"current_openweathermap", func(r *ui.Renderer) { r.DisplayCurrentWeather(owm) }},
+		{"advice", func(r *ui.Renderer) { r.DisplayAdvice(data) }},
+		{"advice_hot", func(r *ui.Renderer) { r.DisplayAdvice(&hot) }},
+		{"advice_cold", func(r *ui.Renderer) { r.DisplayAdvice(&cold) }},
+		{"advice_openweathermap", func(r *ui.Renderer) { r.DisplayAdvice(owm) }},
+		{"advice_empty", func(r *ui.Renderer) { r.DisplayAdvice(empty) }},
+		{"forecast", func(r *ui.Renderer) { r.DisplayForecast(data) }},
+		{"hourly", func(r *ui.Renderer) { r.DisplayHourlyForecast(data.Forecast.ForecastDay[0]) }},
+		{"temperature_chart", func(r *ui.Renderer) { r.DisplayTemperatureChart(data) }},
+		{"precipitation_chart", func(r *ui.Renderer) { r.DisplayPrecipitationChart(data.Forecast.ForecastDay[1]) }},
+		{"alerts", func(r *ui.Renderer) { r.CheckAlerts(data) }},
+		{"alert_settings", func(r *ui.Renderer) { r.DisplayAlertSettings(testSettings.AlertThresholds) }},
+		{"dashboard", func(r *ui.Renderer) { r.DisplayDashboard(data) }},
+		{"dashboard_extended", func(r *ui.Renderer) { r.DisplayExtendedDashboard(data, true) }},
+		{"dashboard_compact", func(r *ui.Renderer) { r.DisplayCompactDashboard(data) }},
+		{"compare", func(r *ui.Renderer) { r.DisplayLocationComparison(data, owm) }},
+		{"forecast_openweathermap", func(r *ui.Renderer) { r.DisplayForecast(owm) }},
+		{"dashboard_empty", func(r *ui.Renderer) { r.DisplayExtendedDashboard(empty, true) }},
+		{"dashboard_compact_empty", func(r *ui.Renderer) { r.DisplayCompactDashboard(empty) }},
+		{"wind", func(r *ui.Renderer) { r.DisplayWind(data) }},
+		{"wind_openweathermap", func(r *ui.Renderer) { r.DisplayWind(owm) }},
+		{"wind_empty", func(r *ui.Renderer) { r.DisplayWind(empty) }},
+		{"heatmap_temp", func(r *ui.Renderer) { r.DisplayHeatmap(data, heatmapMetric(t, "temp")) }},
+		{"heatmap_rain", func(r *ui.Renderer) { r.DisplayHeatmap(data, heatmapMetric(t, "rain")) }},
+		{"heatmap_wind", func(r *ui.Renderer) { r.DisplayHeatmap(data, heatmapMetric(t, "wind")) }},
+		{"heatmap_uv", func(r *ui.Renderer) { r.DisplayHeatmap(data, heatmapMetric(t, "uv")) }},
+		{"heatmap_openweathermap", func(r *ui.Renderer) { r.DisplayHeatmap(owm, heatmapMetric(t, "temp")) }},
+		{"heatmap_uv_openweathermap", func(r *ui.Renderer) { r.DisplayHeatmap(owm, heatmapMetric(t, "uv")) }},
+		{"heatmap_empty", func(r *ui.Renderer) { r.DisplayHeatmap(empty, heatmapMetric(t, "temp")) }},
+		{"commute", func(r *ui.Renderer) { r.DisplayCommute(early, early, commute) }},
+		{"commute_later", func(r *ui.Renderer) { r.DisplayCommute(data, owm, commute) }},
+		{"commute_empty", func(r *ui.Renderer) { r.DisplayCommute(empty, empty, commute) }},
+		{"plan_running", func(r *ui.Renderer) { r.DisplayPlan(data, plannedActivity(t, "running"), activity.DefaultMinScore) }},
+		{"plan_photography", func(r *ui.Renderer) { r.DisplayPlan(data, plannedActivity(t, "photography"), activity.DefaultMinScore) }},
+		{"plan_openweathermap", func(r *ui.Renderer) { r.DisplayPlan(owm, plannedActivity(t, "cycling"), activity.DefaultMinScore) }},
+		{"plan_no_windows", func(r *ui.Renderer) { r.DisplayPlan(data, plannedActivity(t, "bbq"), 95) }},
+		{"plan_empty", func(r *ui.Renderer) { r.DisplayPlan(empty, plannedActivity(t, "hiking"), activity.DefaultMinScore) }},
+		{"favorites", func(r *ui.Renderer) { r.DisplayFavorites([]*model.WeatherData{data, owm, empty}) }},
+		{"favorites_empty", func(r *ui.Renderer) { r.DisplayFavorites(nil) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.render(ui.NewRenderer(&buf, testSettings))
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestGoldenClimate(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+	climate := &model.Climate{
+		Location: "London",
+		Days: map[string]model.ClimateDay{
+			"10-17": {MonthDay: "10-17", Samples: 45, NormalTempC: 13, NormalMaxC: 15.1, NormalMinC: 8.4,
+				P10TempC: 10.2, P50TempC: 12.9, P90TempC: 15.8,
+				RecordHighC: 16.1, RecordHighDate: "2023-10-17", RecordLowC: 1.2, RecordLowDate: "2024-10-17"},
+			"10-18": {MonthDay: "10-18", Samples: 45, NormalTempC: 12.8, NormalMaxC: 14.9, NormalMinC: 8.2,
+				P10TempC: 10, P50TempC: 12.7, P90TempC: 15.5},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		render func(r *ui.Renderer)
+	}{
+		{"current_climate", func(r *ui.Renderer) { r.DisplayCurrentWeather(data) }},
+		{"forecast_climate", func(r *ui.Renderer) { r.DisplayForecast(data) }},
+		{"climate", func(r *ui.Renderer) { r.DisplayClimate(climate, "2025-10-17") }},
+		{"climate_missing", func(r *ui.Renderer) { r.DisplayClimate(climate, "2025-03-01") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := ui.NewRenderer(&buf, testSettings)
+			r.SetClimate(climate)
+			tt.render(r)
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestGoldenTemplate(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+
+	var buf bytes.Buffer
+	r := ui.NewRenderer(&buf, testSettings)
+	tmpl, err := r.LoadTemplate(filepath.Join("testdata", "standup.tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DisplayTemplate(tmpl, data); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "template_standup", buf.Bytes())
+}
+
+func TestGoldenAccessible(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+	owm := fetch(t, api.ProviderOpenWeatherMap, apitest.LocationOK, 3)
+	empty := fetch(t, api.ProviderWeatherAPI, apitest.LocationEmpty, 3)
+
+	settings := testSettings
+	settings.Accessible = true
+
+	tests := []struct {
+		name   string
+		render func(r *ui.Renderer)
+	}{
+		{"accessible_dashboard", func(r *ui.Renderer) { r.DisplayExtendedDashboard(data, true) }},
+		{"accessible_dashboard_compact", func(r *ui.Renderer) { r.DisplayCompactDashboard(data) }},
+		{"accessible_precipitation_chart", func(r *ui.Renderer) { r.DisplayPrecipitationChart(data.Forecast.ForecastDay[1]) }},
+		{"accessible_compare", func(r *ui.Renderer) { r.DisplayLocationComparison(data, owm) }},
+		{"accessible_dashboard_empty", func(r *ui.Renderer) { r.DisplayExtendedDashboard(empty, true) }},
+		{"accessible_wind", func(r *ui.Renderer) { r.DisplayWind(data) }},
+		{"accessible_heatmap", func(r *ui.Renderer) { r.DisplayHeatmap(data, heatmapMetric(t, "temp")) }},
+		{"accessible_advice", func(r *ui.Renderer) { r.DisplayAdvice(data) }},
+		{"accessible_commute", func(r *ui.Renderer) { r.DisplayCommute(earlyCommute(t), earlyCommute(t), commute) }},
+		{"accessible_plan", func(r *ui.Renderer) { r.DisplayPlan(data, plannedActivity(t, "running"), activity.DefaultMinScore) }},
+		{"accessible_favorites", func(r *ui.Renderer) { r.DisplayFavorites([]*model.WeatherData{data, owm}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.render(ui.NewRenderer(&buf, settings))
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func TestGoldenLocales(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+
+	for _, tag := range []string{"es", "fr", "de", "qu"} {
+		t.Run(tag, func(t *testing.T) {
+			locale, err := i18n.Lookup(tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			settings := testSettings
+			settings.Locale = locale
+
+			var buf bytes.Buffer
+			ui.NewRenderer(&buf, settings).DisplayExtendedDashboard(data, true)
+			assertGolden(t, "locale_"+tag, buf.Bytes())
+		})
+	}
+}
+
+func TestGoldenWidths(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+	owm := fetch(t, api.ProviderOpenWeatherMap, apitest.LocationOK, 3)
+
+	// Sparklines narrow with the terminal and disappear when it is too
+	// narrow for them
+	for _, width := range []int{40, 120} {
+		settings := testSettings
+		settings.Width = width
+
+		tests := []struct {
+			name   string
+			render func(r *ui.Renderer)
+		}{
+			{"dashboard_compact", func(r *ui.Renderer) { r.DisplayCompactDashboard(data) }},
+			{"compare", func(r *ui.Renderer) { r.DisplayLocationComparison(data, owm) }},
+			{"favorites", func(r *ui.Renderer) { r.DisplayFavorites([]*model.WeatherData{data, owm}) }},
+		}
+		for _, tt := range tests {
+			name := fmt.Sprintf("%s_width_%d", tt.name, width)
+			t.Run(name, func(t *testing.T) {
+				var buf bytes.Buffer
+				tt.render(ui.NewRenderer(&buf, settings))
+				assertGolden(t, name, buf.Bytes())
+			})
+		}
+	}
+}
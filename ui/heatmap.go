@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/model"
+)
+
+// DefaultHeatmapMetric is the metric a heatmap shows unless another is
+// chosen
+const DefaultHeatmapMetric = "temp"
+
+// HeatmapMetric is an hourly value shown by a forecast heatmap
+type HeatmapMetric struct {
+	Name   string
+	title  string
+	format string
+	value  func(model.Hour) float64
+	roles  []Role
+
+	// supplied reports whether a provider reports the metric, and missing
+	// says so otherwise, with %s for the provider; a nil supplied means
+	// every provider does
+	supplied func(provider string) bool
+	missing  string
+
+	// lo and hi fix the ends of the scale; when both are zero the scale
+	// spans the forecast's own values
+	lo, hi float64
+}
+
+// severityRoles color values that grow more hazardous from low to high
+var severityRoles = []Role{RoleOK, RoleOK, RoleCaution, RoleWarning, RoleDanger}
+
+// heatmapShades draw the steps of a heatmap's scale, lowest first, so that
+// it reads without color too
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmapMetrics are the available metrics by name
+var heatmapMetrics = map[string]*HeatmapMetric{
+	"temp": {
+		Name:   "temp",
+		title:  "Temperature by Hour",
+		format: "%.0f°C",
+		value:  func(h model.Hour) float64 { return h.TempC },
+		roles:  temperatureRoles,
+	},
+	"rain": {
+		Name:   "rain",
+		title:  "Chance of Rain by Hour",
+		format: "%.0f%%",
+		value:  func(h model.Hour) float64 { return float64(h.ChanceOfRain) },
+		roles:  rainRoles,
+		hi:     100,
+	},
+	"wind": {
+		Name:   "wind",
+		title:  "Wind by Hour",
+		format: "%.0f km/h",
+		value:  func(h model.Hour) float64 { return h.WindKph },
+		roles:  severityRoles,
+		hi:     62, // a gale on the Beaufort scale
+	},
+	"uv": {
+		Name:     "uv",
+		title:    "UV Index by Hour",
+		format:   "UV %.0f",
+		value:    func(h model.Hour) float64 { return h.UV },
+		roles:    severityRoles,
+		hi:       11, // extreme
+		supplied: api.SuppliesUV,
+		missing:  "UV not available from %s",
+	},
+}
+
+// HeatmapMetricNames returns the names of the available metrics, sorted
+func HeatmapMetricNames() []string {
+	names := make([]string, 0, len(heatmapMetrics))
+	for name := range heatmapMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupHeatmapMetric returns the metric with the given name
+func LookupHeatmapMetric(name string) (*HeatmapMetric, error) {
+	metric, ok := heatmapMetrics[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown heatmap metric %q (choose from %s)", name, strings.Join(HeatmapMetricNames(), ", "))
+	}
+	return metric, nil
+}
+
+// heatmapRow is one forecast day's hours by hour of day
+type heatmapRow struct {
+	date  string
+	hours [24]*model.Hour
+}
+
+// heatmapRows lays out the forecast's hours by day and hour of day. Hours
+// whose time cannot be read, or whose value is not a number, are left out.
+func heatmapRows(days []model.ForecastDay, metric *HeatmapMetric) []heatmapRow {
+	rows := make([]heatmapRow, len(days))
+	for i := range days {
+		rows[i].date = days[i].Date
+		for j := range days[i].Hour {
+			hour := &days[i].Hour[j]
+			t, err := time.Parse("2006-01-02 15:04", hour.Time)
+			v := metric.value(*hour)
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			rows[i].hours[t.Hour()] = hour
+		}
+	}
+	return rows
+}
+
+// DisplayHeatmap outputs the forecast as a grid of days by hours, each
+// cell shaded and colored by the metric's value
+func (r *Renderer) DisplayHeatmap(data *model.WeatherData, metric *HeatmapMetric) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.t(metric.title))
+	fmt.Fprintln(r.w)
+
+	if metric.supplied != nil && !metric.supplied(data.Provider) {
+		r.paint(RoleMuted).Fprintf(r.w, "  %s\n\n", r.tf(metric.missing, data.Provider))
+		return
+	}
+
+	rows := heatmapRows(data.Forecast.ForecastDay, metric)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, row := range rows {
+		for _, hour := range row.hours {
+			if hour != nil {
+				lo = math.Min(lo, metric.value(*hour))
+				hi = math.Max(hi, metric.value(*hour))
+			}
+		}
+	}
+	if math.IsInf(lo, 1) {
+		r.placeholder("No hourly forecast data available")
+		return
+	}
+	if metric.lo != 0 || metric.hi != 0 {
+		lo, hi = metric.lo, metric.hi
+	}
+
+	if r.settings.Accessible {
+		for _, row := range rows {
+			r.describeHeatmapRow(row, metric)
+		}
+		fmt.Fprintln(r.w)
+	} else {
+		r.drawHeatmap(rows, metric, lo, hi)
+	}
+
+	if metric.Name == "temp" {
+		r.displayWarmestDryHour(data.Forecast.ForecastDay)
+	}
+}
+
+// drawHeatmap draws one row of cells per day, two columns per hour, under
+// a header marking every third hour, and a legend of the scale
+func (r *Renderer) drawHeatmap(rows []heatmapRow, metric *HeatmapMetric, lo, hi float64) {
+	labels := make([]string, len(rows))
+	for i, row := range rows {
+		labels[i] = r.calendarDay(row.date)
+	}
+	width := labelWidth(labels...) + 1
+
+	header := r.paint(RoleHeader)
+	fmt.Fprint(r.w, repeatChar(" ", width))
+	for h := 0; h < 24; h += 3 {
+		if h > 0 {
+			fmt.Fprint(r.w, "    ")
+		}
+		header.Fprintf(r.w, "%02d", h)
+	}
+	fmt.Fprintln(r.w)
+
+	label := r.paint(RoleLabel)
+	for i, row := range rows {
+		label.Fprintf(r.w, "%-*s", width, labels[i])
+		for _, hour := range row.hours {
+			if hour == nil {
+				fmt.Fprint(r.w, "  ")
+				continue
+			}
+			r.heatmapCell(metric, metric.value(*hour), lo, hi)
+		}
+		fmt.Fprintln(r.w)
+	}
+	fmt.Fprintln(r.w)
+
+	// The legend runs from the low end of the scale to the high end
+	fmt.Fprint(r.w, repeatChar(" ", width))
+	r.paint(RoleMuted).Fprint(r.w, r.tf(metric.format, lo)+" ")
+	for i := range heatmapShades {
+		step := lo + (hi-lo)*(float64(i)+0.5)/float64(len(heatmapShades))
+		r.heatmapCell(metric, step, lo, hi)
+	}
+	r.paint(RoleMuted).Fprintln(r.w, " "+r.tf(metric.format, hi))
+	fmt.Fprintln(r.w)
+}
+
+// heatmapCell draws a two-column cell for value on the scale from lo to hi
+func (r *Renderer) heatmapCell(metric *HeatmapMetric, value, lo, hi float64) {
+	shade := heatmapShades[scaleIndex(len(heatmapShades), value, lo, hi)]
+	role := metric.roles[scaleIndex(len(metric.roles), value, lo, hi)]
+	r.paint(role).Fprint(r.w, shade+shade)
+}
+
+// describeHeatmapRow writes a day's lowest and highest values and when
+// they occur, for screen readers
+func (r *Renderer) describeHeatmapRow(row heatmapRow, metric *HeatmapMetric) {
+	var low, high *model.Hour
+	for _, hour := range row.hours {
+		if hour == nil {
+			continue
+		}
+		if low == nil || metric.value(*hour) < metric.value(*low) {
+			low = hour
+		}
+		if high == nil || metric.value(*hour) > metric.value(*high) {
+			high = hour
+		}
+	}
+	if low == nil {
+		return
+	}
+	r.paint(RoleText).Fprintln(r.w, r.tf("%s: lowest %s at %s, highest %s at %s",
+		r.calendarDay(row.date),
+		r.tf(metric.format, metric.value(*low)), hourLabel(low.Time),
+		r.tf(metric.format, metric.value(*high)), hourLabel(high.Time)))
+}
+
+// displayWarmestDryHour names the warmest hour of the forecast when rain
+// is unlikely
+func (r *Renderer) displayWarmestDryHour(days []model.ForecastDay) {
+	var best *model.Hour
+	var bestDate string
+	for i := range days {
+		for j := range days[i].Hour {
+			hour := &days[i].Hour[j]
+			if hour.ChanceOfRain >= likelyRain || math.IsNaN(hour.TempC) {
+				continue
+			}
+			if best == nil || hour.TempC > best.TempC {
+				best, bestDate = hour, days[i].Date
+			}
+		}
+	}
+	if best == nil {
+		return
+	}
+	r.paint(RoleAccent).Fprintf(r.w, "%s%s\n\n", r.mark("☀️ "), r.tf("Warmest dry hour: %s at %s, %.0f°C",
+		r.calendarDay(bestDate), hourLabel(best.Time), best.TempC))
+}
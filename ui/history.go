@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// DisplayHistory outputs observed weather returned by the provider
+func (r *Renderer) DisplayHistory(data *model.HistoricalData) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.tf("Weather History: %s, %s", data.Location.Name, data.Location.Country))
+	fmt.Fprintln(r.w)
+
+	if len(data.Forecast.ForecastDay) == 0 {
+		r.placeholder("No history available for this date")
+		return
+	}
+
+	table := r.newTable([]string{r.t("Date"), r.t("Condition"), r.t("Max"), r.t("Min"), r.t("Avg"), r.t("Precip"), r.t("Max Wind")})
+	for _, day := range data.Forecast.ForecastDay {
+		table.Append([]string{
+			r.date(day.Date),
+			r.mark(r.icon(day.Day.Condition, true)+" ") + r.conditionText(day.Day.Condition),
+			r.tf("%.1f°C", day.Day.MaxTempC),
+			r.tf("%.1f°C", day.Day.MinTempC),
+			r.tf("%.1f°C", day.Day.AvgTempC),
+			r.tf("%.1f mm", day.Day.TotalPrecipMm),
+			r.tf("%.1f km/h", day.Day.MaxWindKph),
+		})
+	}
+	table.Render()
+	fmt.Fprintln(r.w)
+}
+
+// DisplayObservedDays outputs daily summaries from the local history database
+func (r *Renderer) DisplayObservedDays(location string, days []model.ObservedDay) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.tf("Recorded History: %s", location))
+	fmt.Fprintln(r.w)
+
+	if len(days) == 0 {
+		r.placeholder("Nothing recorded yet; run 'illapaca log' to start recording")
+		return
+	}
+
+	table := r.newTable([]string{r.t("Date"), r.t("Max"), r.t("Min"), r.t("Avg"), r.t("Max Wind"), r.t("Rain"), r.t("Readings")})
+	for _, day := range days {
+		rain := r.t("No")
+		if day.Rained {
+			rain = r.t("Yes")
+		}
+		table.Append([]string{
+			r.date(day.Date),
+			r.tf("%.1f°C", day.MaxTempC),
+			r.tf("%.1f°C", day.MinTempC),
+			r.tf("%.1f°C", day.AvgTempC),
+			r.tf("%.1f km/h", day.MaxWindKph),
+			rain,
+			fmt.Sprintf("%d", day.Readings),
+		})
+	}
+	table.Render()
+	fmt.Fprintln(r.w)
+}
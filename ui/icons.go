@@ -1,41 +1,417 @@
 package ui
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// conditionKind groups the provider condition codes that share an icon
+type conditionKind int
+
+const (
+	kindUnknown conditionKind = iota
+	kindClear
+	kindPartlyCloudy
+	kindCloudy
+	kindFog
+	kindDrizzle
+	kindRain
+	kindShowers
+	kindThunder
+	kindSleet
+	kindHail
+	kindSnow
+	kindBlizzard
 )
 
-// WeatherIcons maps weather condition text to appropriate emoji
-var weatherIcons = map[string]string{
-	"sunny":         "☀️",
-	"clear":         "☀️",
-	"partly cloudy": "⛅",
-	"cloudy":        "☁️",
-	"overcast":      "☁️",
-	"mist":          "🌫️",
-	"rain":          "🌧️",
-	"snow":          "❄️",
-	"storm":         "⛈️",
-	"fog":           "🌫️",
-	"thunderstorm":  "🌩️",
-	"drizzle":       "🌦️",
-	"ice":           "🧊",
-	"sleet":         "🌨️",
-	"hail":          "🌨️",
-	"windy":         "💨",
-	"tornado":       "🌪️",
-	"hurricane":     "🌀",
-}
-
-// GetConditionIcon returns the appropriate weather icon for a condition
-func GetConditionIcon(condition string) string {
-	conditionLower := strings.ToLower(condition)
-
-	for k, v := range weatherIcons {
-		if strings.Contains(conditionLower, k) {
-			return v
+// kindNames spell out each kind, for conditions that come without text or
+// in languages the providers do not support
+var kindNames = map[conditionKind]string{
+	kindUnknown:      "Unknown conditions",
+	kindClear:        "Clear",
+	kindPartlyCloudy: "Partly cloudy",
+	kindCloudy:       "Cloudy",
+	kindFog:          "Fog",
+	kindDrizzle:      "Drizzle",
+	kindRain:         "Rain",
+	kindShowers:      "Showers",
+	kindThunder:      "Thunderstorm",
+	kindSleet:        "Sleet",
+	kindHail:         "Hail",
+	kindSnow:         "Snow",
+	kindBlizzard:     "Blizzard",
+}
+
+// conditionKinds maps WeatherAPI.com condition codes, which the
+// OpenWeatherMap provider also produces, to their kind. A condition with
+// thunder is always kindThunder, whatever else falls with it.
+var conditionKinds = map[int]conditionKind{
+	1000: kindClear,
+	1003: kindPartlyCloudy,
+	1006: kindCloudy,
+	1009: kindCloudy,
+	1030: kindFog,
+	1135: kindFog,
+	1147: kindFog,
+	1063: kindShowers,
+	1150: kindDrizzle,
+	1153: kindDrizzle,
+	1072: kindDrizzle,
+	1168: kindDrizzle,
+	1171: kindDrizzle,
+	1180: kindShowers,
+	1183: kindRain,
+	1186: kindRain,
+	1189: kindRain,
+	1192: kindRain,
+	1195: kindRain,
+	1198: kindRain,
+	1201: kindRain,
+	1240: kindShowers,
+	1243: kindShowers,
+	1246: kindShowers,
+	1069: kindSleet,
+	1204: kindSleet,
+	1207: kindSleet,
+	1249: kindSleet,
+	1252: kindSleet,
+	1237: kindHail,
+	1261: kindHail,
+	1264: kindHail,
+	1066: kindSnow,
+	1210: kindSnow,
+	1213: kindSnow,
+	1216: kindSnow,
+	1219: kindSnow,
+	1222: kindSnow,
+	1225: kindSnow,
+	1255: kindSnow,
+	1258: kindSnow,
+	1114: kindBlizzard,
+	1117: kindBlizzard,
+	1087: kindThunder,
+	1273: kindThunder,
+	1276: kindThunder,
+	1279: kindThunder,
+	1282: kindThunder,
+}
+
+// textKinds classifies conditions without a known code by their text. The
+// first match wins, so more severe or specific words come first.
+var textKinds = []struct {
+	word string
+	kind conditionKind
+}{
+	{"thunder", kindThunder},
+	{"storm", kindThunder},
+	{"blizzard", kindBlizzard},
+	{"blowing snow", kindBlizzard},
+	{"sleet", kindSleet},
+	{"ice pellets", kindHail},
+	{"hail", kindHail},
+	{"snow", kindSnow},
+	{"shower", kindShowers},
+	{"patchy rain", kindShowers},
+	{"drizzle", kindDrizzle},
+	{"rain", kindRain},
+	{"fog", kindFog},
+	{"mist", kindFog},
+	{"haze", kindFog},
+	{"partly", kindPartlyCloudy},
+	{"cloud", kindCloudy},
+	{"overcast", kindCloudy},
+	{"sunny", kindClear},
+	{"clear", kindClear},
+}
+
+// classify returns the kind of a condition, by code when the code is known
+// and by text otherwise
+func classify(c model.Condition) conditionKind {
+	if kind, ok := conditionKinds[c.Code]; ok {
+		return kind
+	}
+	text := strings.ToLower(c.Text)
+	for _, tk := range textKinds {
+		if strings.Contains(text, tk.word) {
+			return tk.kind
 		}
 	}
+	return kindUnknown
+}
+
+// icon is a glyph by day and, if different, by night
+type icon struct {
+	day, night string
+}
+
+// IconSet draws conditions as icons, and optionally as multi-line art
+type IconSet struct {
+	Name    string
+	icons   map[conditionKind]icon
+	art     map[conditionKind][2][]string
+	unknown string
+}
+
+// Icon returns the icon for a condition by day or by night
+func (s *IconSet) Icon(c model.Condition, isDay bool) string {
+	i, ok := s.icons[classify(c)]
+	if !ok {
+		return s.unknown
+	}
+	if !isDay && i.night != "" {
+		return i.night
+	}
+	return i.day
+}
+
+// Art returns the multi-line art for a condition, or nil if the set has
+// none. Every line has the same width.
+func (s *IconSet) Art(c model.Condition, isDay bool) []string {
+	if s.art == nil {
+		return nil
+	}
+	art, ok := s.art[classify(c)]
+	if !ok {
+		art = s.art[kindUnknown]
+	}
+	if !isDay && art[1] != nil {
+		return art[1]
+	}
+	return art[0]
+}
+
+var emojiIcons = map[conditionKind]icon{
+	kindClear:        {"☀️", "🌙"},
+	kindPartlyCloudy: {"⛅", "☁️"},
+	kindCloudy:       {"☁️", ""},
+	kindFog:          {"🌫️", ""},
+	kindDrizzle:      {"🌦️", "🌧️"},
+	kindRain:         {"🌧️", ""},
+	kindShowers:      {"🌦️", "🌧️"},
+	kindThunder:      {"⛈️", ""},
+	kindSleet:        {"🌨️", ""},
+	kindHail:         {"🧊", ""},
+	kindSnow:         {"❄️", ""},
+	kindBlizzard:     {"🌬️", ""},
+}
+
+// nerdIcons are Weather Icons glyphs as patched into Nerd Fonts
+var nerdIcons = map[conditionKind]icon{
+	kindClear:        {"\ue30d", "\ue32b"},
+	kindPartlyCloudy: {"\ue302", "\ue37e"},
+	kindCloudy:       {"\ue312", ""},
+	kindFog:          {"\ue303", "\ue346"},
+	kindDrizzle:      {"\ue30b", "\ue328"},
+	kindRain:         {"\ue308", "\ue325"},
+	kindShowers:      {"\ue309", "\ue326"},
+	kindThunder:      {"\ue30f", "\ue32a"},
+	kindSleet:        {"\ue3aa", "\ue3ac"},
+	kindHail:         {"\ue304", "\ue321"},
+	kindSnow:         {"\ue30a", "\ue327"},
+	kindBlizzard:     {"\ue35e", ""},
+}
+
+// asciiIcons are three-letter codes that keep columns aligned anywhere
+var asciiIcons = map[conditionKind]icon{
+	kindClear:        {"SUN", "CLR"},
+	kindPartlyCloudy: {"PCL", ""},
+	kindCloudy:       {"CLD", ""},
+	kindFog:          {"FOG", ""},
+	kindDrizzle:      {"DRZ", ""},
+	kindRain:         {"RAN", ""},
+	kindShowers:      {"SHW", ""},
+	kindThunder:      {"TSM", ""},
+	kindSleet:        {"SLT", ""},
+	kindHail:         {"HAL", ""},
+	kindSnow:         {"SNW", ""},
+	kindBlizzard:     {"BLZ", ""},
+}
+
+// asciiArt is drawn beside the current conditions by the art set. Each
+// entry has day art and, where it differs, night art.
+var asciiArt = map[conditionKind][2][]string{
+	kindUnknown: {{
+		"    .-.      ",
+		"     __)     ",
+		"    (        ",
+		"     `-'     ",
+		"      o      ",
+	}},
+	kindClear: {{
+		"    \\   /    ",
+		"     .-.     ",
+		"  - (   ) -  ",
+		"     `-'     ",
+		"    /   \\    ",
+	}, {
+		"     .--.    ",
+		"    / .'  *  ",
+		"   |  |      ",
+		"    \\ '.  *  ",
+		"     '--'    ",
+	}},
+	kindPartlyCloudy: {{
+		"   \\  /      ",
+		" _ /\"\".-.    ",
+		"   \\_(   ).  ",
+		"   /(___(__) ",
+		"             ",
+	}, {
+		"    .-.  *   ",
+		"   ( (.-.    ",
+		"    `(   ).  ",
+		"    (___(__) ",
+		"             ",
+	}},
+	kindCloudy: {{
+		"             ",
+		"     .--.    ",
+		"  .-(    ).  ",
+		" (___.__)__) ",
+		"             ",
+	}},
+	kindFog: {{
+		"             ",
+		" _ - _ - _ - ",
+		"  _ - _ - _  ",
+		" _ - _ - _ - ",
+		"             ",
+	}},
+	kindDrizzle: {{
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"    ' ' ' '  ",
+		"   ' ' ' '   ",
+	}},
+	kindRain: {{
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"  ,',',','   ",
+		"  ,',',','   ",
+	}},
+	kindShowers: {{
+		" _`/\"\".-.    ",
+		"  ,\\_(   ).  ",
+		"   /(___(__) ",
+		"     ' ' ' ' ",
+		"    ' ' ' '  ",
+	}, {
+		"    .-.  *   ",
+		"   ( (.-.    ",
+		"    `(   ).  ",
+		"    (___(__) ",
+		"    ' ' ' '  ",
+	}},
+	kindThunder: {{
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"   ,'/_,'/_  ",
+		"   ,'/ ,'/   ",
+	}},
+	kindSleet: {{
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"    ' * ' *  ",
+		"   * ' * '   ",
+	}},
+	kindHail: {{
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"    o  o  o  ",
+		"   o  o  o   ",
+	}},
+	kindSnow: {{
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"    *  *  *  ",
+		"   *  *  *   ",
+	}},
+	kindBlizzard: {{
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"  - * - * -  ",
+		" * - * - * - ",
+	}},
+}
+
+// iconSets are the selectable sets by name
+var iconSets = map[string]*IconSet{
+	"emoji": {Name: "emoji", icons: emojiIcons, unknown: "🌡️"},
+	"nerd":  {Name: "nerd", icons: nerdIcons, unknown: "\ue374"},
+	"ascii": {Name: "ascii", icons: asciiIcons, unknown: "???"},
+	"art":   {Name: "art", icons: asciiIcons, art: asciiArt, unknown: "???"},
+}
+
+// DefaultIconSet is used when none is chosen
+const DefaultIconSet = "emoji"
+
+// IconSetNames returns the selectable icon set names, sorted
+func IconSetNames() []string {
+	names := make([]string, 0, len(iconSets))
+	for name := range iconSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
 
-	// Default icon if no match
-	return "🌡️"
+// LookupIconSet returns the icon set called name
+func LookupIconSet(name string) (*IconSet, error) {
+	set, ok := iconSets[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown icon set %q (choose from %s)", name, strings.Join(IconSetNames(), ", "))
+	}
+	return set, nil
+}
+
+// ConditionIcon returns the emoji for a condition, for output that does
+// not follow the configured icon set, such as calendar feeds
+func ConditionIcon(c model.Condition, isDay bool) string {
+	return iconSets[DefaultIconSet].Icon(c, isDay)
+}
+
+// icons returns the renderer's icon set
+func (r *Renderer) icons() *IconSet {
+	if r.settings.Icons != nil {
+		return r.settings.Icons
+	}
+	return iconSets[DefaultIconSet]
+}
+
+// icon returns the icon for a condition in the renderer's icon set, or
+// the condition in words in accessible mode
+func (r *Renderer) icon(c model.Condition, isDay bool) string {
+	if r.settings.Accessible {
+		return r.conditionText(c)
+	}
+	return r.icons().Icon(c, isDay)
+}
+
+// conditionText names a condition in the renderer's language. Providers
+// translate their own condition text; for languages they do not support
+// the condition's kind is translated instead.
+func (r *Renderer) conditionText(c model.Condition) string {
+	if r.locale().ProviderLang == "" || c.Text == "" {
+		return r.t(kindNames[classify(c)])
+	}
+	return c.Text
+}
+
+// compass translates a compass direction such as "SW" letter by letter
+func (r *Renderer) compass(dir string) string {
+	var b strings.Builder
+	for _, point := range dir {
+		b.WriteString(r.t(string(point)))
+	}
+	return b.String()
 }
@@ -0,0 +1,90 @@
+package ui_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/model"
+	"github.com/biferdou/illapaca/ui"
+)
+
+func TestIcons(t *testing.T) {
+	emoji, _ := ui.LookupIconSet("emoji")
+	ascii, _ := ui.LookupIconSet("ascii")
+
+	tests := []struct {
+		set   *ui.IconSet
+		c     model.Condition
+		isDay bool
+		want  string
+	}{
+		{emoji, model.Condition{Code: 1000, Text: "Sunny"}, true, "☀️"},
+		{emoji, model.Condition{Code: 1000, Text: "Clear"}, false, "🌙"},
+		{emoji, model.Condition{Code: 1009, Text: "Overcast"}, false, "☁️"},
+		{emoji, model.Condition{Code: 1273, Text: "Patchy light rain with thunder"}, true, "⛈️"},
+		{emoji, model.Condition{Code: 1279, Text: "Patchy light snow with thunder"}, true, "⛈️"},
+		{emoji, model.Condition{Code: 1198, Text: "Light freezing rain"}, true, "🌧️"},
+		{ascii, model.Condition{Code: 1243, Text: "Moderate or heavy rain shower"}, true, "SHW"},
+		{ascii, model.Condition{Code: 1117, Text: "Blizzard"}, true, "BLZ"},
+
+		// Without a known code the text decides, most severe word first
+		{emoji, model.Condition{Text: "Patchy light rain with thunder"}, true, "⛈️"},
+		{emoji, model.Condition{Text: "Broken clouds"}, true, "☁️"},
+		{ascii, model.Condition{Text: "Light snow showers"}, true, "SNW"},
+		{ascii, model.Condition{Text: "Volcanic ash"}, true, "???"},
+	}
+	for _, tt := range tests {
+		// Repeat to catch any order dependence
+		for range 20 {
+			if got := tt.set.Icon(tt.c, tt.isDay); got != tt.want {
+				t.Fatalf("%s %q day=%v = %q, want %q", tt.set.Name, tt.c.Text, tt.isDay, got, tt.want)
+			}
+		}
+	}
+
+	if _, err := ui.LookupIconSet("wingdings"); err == nil {
+		t.Error("unknown icon set was found")
+	}
+}
+
+func TestIconArt(t *testing.T) {
+	art, _ := ui.LookupIconSet("art")
+	emoji, _ := ui.LookupIconSet("emoji")
+
+	if emoji.Art(model.Condition{Code: 1000}, true) != nil {
+		t.Error("emoji set has art")
+	}
+	for code := 1000; code <= 1282; code++ {
+		for _, isDay := range []bool{true, false} {
+			lines := art.Art(model.Condition{Code: code}, isDay)
+			if len(lines) != 5 {
+				t.Fatalf("code %d: %d lines", code, len(lines))
+			}
+			for _, line := range lines {
+				if len(line) != len(lines[0]) {
+					t.Fatalf("code %d day=%v: uneven line %q", code, isDay, line)
+				}
+			}
+		}
+	}
+}
+
+func TestGoldenIconSets(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+
+	for _, name := range []string{"ascii", "art"} {
+		t.Run(name, func(t *testing.T) {
+			set, _ := ui.LookupIconSet(name)
+			settings := testSettings
+			settings.Icons = set
+
+			var buf bytes.Buffer
+			r := ui.NewRenderer(&buf, settings)
+			r.DisplayCurrentWeather(data)
+			r.DisplayForecast(data)
+			assertGolden(t, "icons_"+name, buf.Bytes())
+		})
+	}
+}
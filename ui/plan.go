@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/biferdou/illapaca/activity"
+	"github.com/biferdou/illapaca/model"
+)
+
+// planWindows is the number of windows a plan suggests
+const planWindows = 3
+
+// scoreRoles color activity scores from poor to good
+var scoreRoles = []Role{RoleDanger, RoleWarning, RoleCaution, RoleOK, RoleOK}
+
+// activityTitles name the built-in activities in titles
+var activityTitles = map[string]string{
+	"running":     "Running",
+	"cycling":     "Cycling",
+	"hiking":      "Hiking",
+	"photography": "Photography",
+	"laundry":     "Laundry",
+	"bbq":         "Barbecue",
+}
+
+// activityTitle names an activity, translated if it is a built-in one
+func (r *Renderer) activityTitle(name string) string {
+	if title, ok := activityTitles[name]; ok {
+		return r.t(title)
+	}
+	return name
+}
+
+// DisplayPlan outputs the best windows for an activity over the forecast,
+// followed by every hour's score
+func (r *Renderer) DisplayPlan(data *model.WeatherData, profile *activity.Profile, minScore int) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.tf("Best Times for %s: %s, %s", r.activityTitle(profile.Name), data.Location.Name, data.Location.Country))
+	fmt.Fprintln(r.w)
+
+	days := profile.Plan(data)
+	if len(days) == 0 {
+		r.placeholder("No hourly forecast data available")
+		return
+	}
+
+	r.displayWindows(days, minScore)
+	if r.settings.Accessible {
+		r.describeScores(days)
+	} else {
+		r.drawScores(days)
+	}
+}
+
+// displayWindows lists the best windows, or names the best hour when no
+// hour reaches minScore
+func (r *Renderer) displayWindows(days []activity.Day, minScore int) {
+	chartTitle := r.paint(RoleChartTitle)
+	chartTitle.Fprintln(r.w, r.t("Suggested Windows"))
+	fmt.Fprintln(r.w)
+
+	windows := activity.BestWindows(days, minScore, planWindows)
+	if len(windows) == 0 {
+		var best activity.HourScore
+		var bestDate string
+		for _, day := range days {
+			for _, h := range day.Hours {
+				if bestDate == "" || h.Score > best.Score {
+					best, bestDate = h, day.Date
+				}
+			}
+		}
+		r.paint(RoleMuted).Fprintf(r.w, "  %s\n\n", r.tf("No hours score %d or more; the best is %s at %s, scoring %d",
+			minScore, r.calendarDay(bestDate), hourLabel(best.Hour.Time), best.Score))
+		return
+	}
+
+	for i, w := range windows {
+		r.displayWindow(i+1, w)
+	}
+	fmt.Fprintln(r.w)
+}
+
+// displayWindow writes a window's time, score and the range of weather
+// over its hours
+func (r *Renderer) displayWindow(n int, w activity.Window) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	rain, wind := 0, 0.0
+	for _, h := range w.Hours {
+		lo, hi = math.Min(lo, h.Hour.TempC), math.Max(hi, h.Hour.TempC)
+		rain = max(rain, h.Hour.ChanceOfRain)
+		wind = math.Max(wind, h.Hour.WindKph)
+	}
+	start := hourLabel(w.Hours[0].Hour.Time)
+	end := windowEnd(w.Hours[len(w.Hours)-1].Hour.Time)
+	temp := r.tf("%.0f°C to %.0f°C", lo, hi)
+	if fmt.Sprintf("%.0f", lo) == fmt.Sprintf("%.0f", hi) {
+		temp = r.tf("%.0f°C", lo)
+	}
+	wet := r.tf("rain up to %d%%", rain)
+	if rain == 0 {
+		wet = r.t("dry")
+	}
+	details := strings.Join([]string{temp, wet, r.tf("wind up to %.0f km/h", wind)}, ", ")
+
+	if r.settings.Accessible {
+		r.paint(RoleText).Fprintln(r.w, r.tf("%d. %s, %s to %s: score %d of 100, %s.",
+			n, r.calendarDay(w.Date), start, end, w.Score(), details))
+		return
+	}
+	fmt.Fprintf(r.w, "  %d. ", n)
+	r.paint(RoleLabel).Fprintf(r.w, "%s %s–%s  ", r.calendarDay(w.Date), start, end)
+	r.paint(scaleRole(scoreRoles, float64(w.Score()), 0, 100)).Fprint(r.w, r.tf("%d/100", w.Score()))
+	r.paint(RoleValue).Fprintf(r.w, "  %s\n", details)
+}
+
+// windowEnd returns the end of the hour starting at timestamp
+func windowEnd(timestamp string) string {
+	t, err := time.Parse("2006-01-02 15:04", timestamp)
+	if err != nil {
+		return "--:--"
+	}
+	return t.Add(time.Hour).Format("15:04")
+}
+
+// drawScores draws a row of scores per day, two columns per hour, under a
+// header marking every third hour, and a legend of the scale
+func (r *Renderer) drawScores(days []activity.Day) {
+	chartTitle := r.paint(RoleChartTitle)
+	chartTitle.Fprintln(r.w, r.t("Hourly Scores"))
+	fmt.Fprintln(r.w)
+
+	labels := make([]string, len(days))
+	for i, day := range days {
+		labels[i] = r.calendarDay(day.Date)
+	}
+	width := labelWidth(labels...) + 1
+
+	header := r.paint(RoleHeader)
+	fmt.Fprint(r.w, repeatChar(" ", width))
+	for h := 0; h < 24; h += 3 {
+		if h > 0 {
+			fmt.Fprint(r.w, "    ")
+		}
+		header.Fprintf(r.w, "%02d", h)
+	}
+	fmt.Fprintln(r.w)
+
+	label := r.paint(RoleLabel)
+	for i, day := range days {
+		var scores [24]*activity.HourScore
+		for j := range day.Hours {
+			if t, err := time.Parse("2006-01-02 15:04", day.Hours[j].Hour.Time); err == nil {
+				scores[t.Hour()] = &day.Hours[j]
+			}
+		}
+		label.Fprintf(r.w, "%-*s", width, labels[i])
+		for _, s := range scores {
+			if s == nil {
+				fmt.Fprint(r.w, "  ")
+				continue
+			}
+			r.scoreCell(float64(s.Score))
+		}
+		fmt.Fprintln(r.w)
+	}
+	fmt.Fprintln(r.w)
+
+	fmt.Fprint(r.w, repeatChar(" ", width))
+	r.paint(RoleMuted).Fprint(r.w, "0 ")
+	for i := range scoreRoles {
+		r.scoreCell(100 * (float64(i) + 0.5) / float64(len(scoreRoles)))
+	}
+	r.paint(RoleMuted).Fprintln(r.w, " 100")
+	fmt.Fprintln(r.w)
+}
+
+// scoreCell draws a two-column cell for a score from 0 to 100
+func (r *Renderer) scoreCell(score float64) {
+	block := sparklineRange([]float64{score}, 0, 100)
+	r.paint(scaleRole(scoreRoles, score, 0, 100)).Fprint(r.w, block+block)
+}
+
+// describeScores writes each day's best and worst hours, for screen
+// readers
+func (r *Renderer) describeScores(days []activity.Day) {
+	chartTitle := r.paint(RoleChartTitle)
+	chartTitle.Fprintln(r.w, r.t("Hourly Scores"))
+	fmt.Fprintln(r.w)
+
+	for _, day := range days {
+		best, worst := day.Hours[0], day.Hours[0]
+		for _, h := range day.Hours[1:] {
+			if h.Score > best.Score {
+				best = h
+			}
+			if h.Score < worst.Score {
+				worst = h
+			}
+		}
+		r.paint(RoleText).Fprintln(r.w, r.tf("%s: best %d at %s, worst %d at %s",
+			r.calendarDay(day.Date), best.Score, hourLabel(best.Hour.Time), worst.Score, hourLabel(worst.Hour.Time)))
+	}
+	fmt.Fprintln(r.w)
+}
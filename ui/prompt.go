@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// DefaultPromptFormat is used when no --format is given
+const DefaultPromptFormat = "{{.Icon}} {{.Temp}}"
+
+// promptPending is shown until the first refresh has completed
+const promptPending = "…"
+
+// PromptPresets lists the status bar presets accepted by DisplayPrompt
+var PromptPresets = []string{"plain", "tmux", "i3bar", "waybar"}
+
+// PromptData is what prompt templates are executed against: the full
+// weather data plus shortcuts for the fields a status line usually needs
+type PromptData struct {
+	*model.WeatherData
+
+	Icon       string
+	Condition  string
+	TempC      float64
+	TempF      float64
+	FeelsLikeC float64
+	FeelsLikeF float64
+	Humidity   int
+	WindKph    float64
+	RainChance int // today's chance of rain, in percent
+
+	// Temp and FeelsLike are rounded and suffixed in the preferred units,
+	// e.g. "16°C"
+	Temp      string
+	FeelsLike string
+
+	// Alerts are the alert messages for today under the configured thresholds
+	Alerts []string
+}
+
+// NewPromptData prepares data for prompt templates
+func (r *Renderer) NewPromptData(data *model.WeatherData) PromptData {
+	p := PromptData{
+		WeatherData: data,
+		Icon:        r.icon(data.Current.Condition, data.Current.IsDay == 1),
+		Condition:   data.Current.Condition.Text,
+		TempC:       data.Current.TempC,
+		TempF:       data.Current.TempF,
+		FeelsLikeC:  data.Current.FeelsLikeC,
+		FeelsLikeF:  data.Current.FeelsLikeF,
+		Humidity:    data.Current.Humidity,
+		WindKph:     data.Current.WindKph,
+		Temp:        fmt.Sprintf("%.0f°C", data.Current.TempC),
+		FeelsLike:   fmt.Sprintf("%.0f°C", data.Current.FeelsLikeC),
+		Alerts:      Alerts(data, r.settings.AlertThresholds),
+	}
+	if r.settings.Units == "imperial" {
+		p.Temp = fmt.Sprintf("%.0f°F", data.Current.TempF)
+		p.FeelsLike = fmt.Sprintf("%.0f°F", data.Current.FeelsLikeF)
+	}
+	if len(data.Forecast.ForecastDay) > 0 {
+		p.RainChance = data.Forecast.ForecastDay[0].Day.DailyChanceOfRain
+	}
+	return p
+}
+
+// ParsePromptFormat parses a prompt template with the helper functions of
+// Funcs, so a bad --format is reported before any weather is loaded
+func (r *Renderer) ParsePromptFormat(format string) (*template.Template, error) {
+	if format == "" {
+		format = DefaultPromptFormat
+	}
+	return template.New("prompt").Funcs(r.Funcs()).Option("missingkey=error").Parse(format)
+}
+
+// DisplayPrompt writes one status line for data in the given preset:
+//
+//	plain   the formatted text
+//	tmux    the text colored with tmux #[fg=...] attributes
+//	i3bar   an i3bar protocol block as JSON
+//	waybar  a waybar custom module object as JSON
+//
+// A nil data means nothing has been fetched yet, and a placeholder is shown.
+func (r *Renderer) DisplayPrompt(data *model.WeatherData, tmpl *template.Template, preset string) error {
+	text := promptPending
+	var p PromptData
+	if data != nil {
+		p = r.NewPromptData(data)
+		var b strings.Builder
+		if err := tmpl.Execute(&b, p); err != nil {
+			return err
+		}
+		text = strings.TrimSpace(b.String())
+	}
+
+	level := promptLevel(data, p)
+
+	switch preset {
+	case "", "plain":
+		fmt.Fprintln(r.w, text)
+	case "tmux":
+		if level == "" {
+			fmt.Fprintln(r.w, text)
+		} else {
+			fmt.Fprintf(r.w, "#[fg=%s]%s#[default]\n", r.promptColor(level), text)
+		}
+	case "i3bar":
+		block := map[string]string{"name": "illapaca", "full_text": text}
+		if data != nil {
+			block["short_text"] = p.Temp
+		}
+		if level != "" {
+			block["color"] = r.promptColor(level)
+		}
+		return r.writePromptJSON(block)
+	case "waybar":
+		module := map[string]interface{}{"text": text}
+		if data != nil {
+			module["tooltip"] = r.promptTooltip(p)
+			module["percentage"] = p.RainChance
+		}
+		if level != "" {
+			module["class"] = level
+		}
+		return r.writePromptJSON(module)
+	default:
+		return fmt.Errorf("unknown preset %q (choose from %s)", preset, strings.Join(PromptPresets, ", "))
+	}
+	return nil
+}
+
+// promptRoles are the theme roles coloring each promptLevel
+var promptRoles = map[string]Role{
+	"alert": RoleDanger,
+	"hot":   RoleHot,
+	"warm":  RoleWarm,
+	"mild":  RoleMild,
+	"cold":  RoleCold,
+}
+
+// promptLevel classifies the weather for status bar styling. Active alerts
+// take precedence over temperature.
+func promptLevel(data *model.WeatherData, p PromptData) string {
+	if data == nil {
+		return ""
+	}
+	switch {
+	case len(p.Alerts) > 0:
+		return "alert"
+	case p.TempC >= 28:
+		return "hot"
+	case p.TempC >= 20:
+		return "warm"
+	case p.TempC >= 10:
+		return "mild"
+	default:
+		return "cold"
+	}
+}
+
+// promptColor returns the theme color of a promptLevel as #rrggbb, which
+// tmux and i3bar both accept
+func (r *Renderer) promptColor(level string) string {
+	return r.theme().Style(promptRoles[level]).Color.Hex()
+}
+
+// promptTooltip describes the conditions in full for hover text
+func (r *Renderer) promptTooltip(p PromptData) string {
+	lines := []string{
+		fmt.Sprintf("%s, %s", p.Location.Name, p.Location.Country),
+		r.tf("%s %s, feels like %s", p.Condition, p.Temp, p.FeelsLike),
+		r.tf("Humidity %d%%, wind %s, rain %d%%", p.Humidity, r.speed(p.WindKph), p.RainChance),
+	}
+	for _, alert := range p.Alerts {
+		lines = append(lines, "⚠ "+alert)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *Renderer) writePromptJSON(v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(r.w, "%s\n", raw)
+	return err
+}
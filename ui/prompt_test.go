@@ -0,0 +1,98 @@
+package ui_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/i18n"
+	"github.com/biferdou/illapaca/model"
+	"github.com/biferdou/illapaca/ui"
+)
+
+func TestDisplayPrompt(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 1)
+
+	tests := []struct {
+		data   *model.WeatherData
+		format string
+		preset string
+		want   string
+	}{
+		{data, "", "plain", "☁️ 16°C\n"},
+		{data, "{{.Icon}} {{.TempC}}°", "plain", "☁️ 16.2°\n"},
+		{data, "{{.Location.Name}}: {{.Condition}}, rain {{.RainChance}}%", "", "London: Cloudy, rain 20%\n"},
+		{data, "{{upper .Location.Name}} {{temp .TempC}}", "", "LONDON 16.2°C\n"},
+		{data, "", "tmux", "#[fg=#00ff00]☁️ 16°C#[default]\n"},
+		{data, "", "i3bar", `{"color":"#00ff00","full_text":"☁️ 16°C","name":"illapaca","short_text":"16°C"}` + "\n"},
+		{data, "{{.Temp}}", "waybar", `{"class":"mild","percentage":20,"text":"16°C","tooltip":"London, United Kingdom\nCloudy 16°C, feels like 15°C\nHumidity 68%, wind 13 km/h, rain 20%"}` + "\n"},
+		{nil, "", "plain", "…\n"},
+		{nil, "", "waybar", `{"text":"…"}` + "\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		r := ui.NewRenderer(&buf, testSettings)
+		tmpl, err := r.ParsePromptFormat(tt.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.DisplayPrompt(tt.data, tmpl, tt.preset); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%q/%s = %q, want %q", tt.format, tt.preset, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestPromptTooltipUnitsAndLocale(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 1)
+	es, err := i18n.Lookup("es")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imperial := testSettings
+	imperial.Units = "imperial"
+	spanish := testSettings
+	spanish.Locale = es
+
+	tests := []struct {
+		name     string
+		settings ui.Settings
+		want     string
+	}{
+		{"imperial", imperial, `"tooltip":"London, United Kingdom\nCloudy 61°F, feels like 59°F\nHumidity 68%, wind 8 mph, rain 20%"`},
+		{"es", spanish, `"tooltip":"London, United Kingdom\nCloudy 16°C, sensación de 15°C\nHumedad 68%, viento 13 km/h, lluvia 20%"`},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		r := ui.NewRenderer(&buf, tt.settings)
+		tmpl, _ := r.ParsePromptFormat("")
+		if err := r.DisplayPrompt(data, tmpl, "waybar"); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("%s: %s, want %s", tt.name, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestDisplayPromptErrors(t *testing.T) {
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 1)
+	r := ui.NewRenderer(&bytes.Buffer{}, testSettings)
+
+	tmpl, _ := r.ParsePromptFormat("{{.Missing}}")
+	if err := r.DisplayPrompt(data, tmpl, "plain"); err == nil {
+		t.Error("unknown field did not fail")
+	}
+	tmpl, _ = r.ParsePromptFormat("")
+	if err := r.DisplayPrompt(data, tmpl, "polybar"); err == nil {
+		t.Error("unknown preset did not fail")
+	}
+	if _, err := r.ParsePromptFormat("{{.Temp"); err == nil {
+		t.Error("malformed format parsed")
+	}
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/biferdou/illapaca/i18n"
+	"github.com/biferdou/illapaca/model"
+	"github.com/fatih/color"
+)
+
+// Settings controls how weather data is rendered
+type Settings struct {
+	// Units is the preferred unit system (metric or imperial)
+	Units string
+
+	// AlertThresholds decide which conditions are flagged as alerts
+	AlertThresholds model.AlertThresholds
+
+	// NoColor disables all color output
+	NoColor bool
+
+	// Theme colors the output; nil means the default theme
+	Theme *Theme
+
+	// ColorDepth is the number of colors the terminal shows. Theme colors
+	// beyond it are approximated.
+	ColorDepth ColorDepth
+
+	// Icons draws weather conditions; nil means emoji
+	Icons *IconSet
+
+	// Accessible suits screen readers: charts become sentences, conditions
+	// are spelled out and tables become labeled lines
+	Accessible bool
+
+	// Locale translates labels and formats numbers and dates; nil means
+	// English
+	Locale *i18n.Locale
+
+	// Width is the number of columns output may use; 0 means DefaultWidth
+	Width int
+}
+
+// Renderer writes weather displays to an io.Writer
+type Renderer struct {
+	w        io.Writer
+	settings Settings
+	climate  *model.Climate
+}
+
+// NewRenderer creates a Renderer writing to w
+func NewRenderer(w io.Writer, settings Settings) *Renderer {
+	return &Renderer{w: w, settings: settings}
+}
+
+// SetClimate supplies climate statistics for the location being shown, so
+// current conditions and forecasts are compared with normal. A nil climate
+// turns the comparison off.
+func (r *Renderer) SetClimate(climate *model.Climate) {
+	r.climate = climate
+}
+
+// locale returns the renderer's locale
+func (r *Renderer) locale() *i18n.Locale {
+	if r.settings.Locale != nil {
+		return r.settings.Locale
+	}
+	return i18n.English
+}
+
+// t translates a message
+func (r *Renderer) t(msg string) string {
+	return r.locale().T(msg)
+}
+
+// tf translates a format string and formats it in the renderer's locale
+func (r *Renderer) tf(format string, args ...interface{}) string {
+	return r.locale().Sprintf(format, args...)
+}
+
+// date formats a YYYY-MM-DD date in the renderer's locale, or returns it
+// unchanged if it cannot be parsed
+func (r *Renderer) date(s string) string {
+	return localDate(r.locale(), s)
+}
+
+// localDate formats a YYYY-MM-DD date in locale l, or returns it unchanged
+// if it cannot be parsed
+func localDate(l *i18n.Locale, s string) string {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return s
+	}
+	return l.Date(t)
+}
+
+// dateTime formats a "YYYY-MM-DD HH:MM" local time in the renderer's
+// locale, or returns it unchanged if it cannot be parsed
+func (r *Renderer) dateTime(s string) string {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		return s
+	}
+	return r.locale().Date(t) + " " + t.Format("15:04")
+}
+
+// clock formats a provider time of day such as "07:27 AM" in the
+// renderer's locale, or returns it unchanged if it cannot be parsed
+func (r *Renderer) clock(s string) string {
+	t, err := time.Parse("03:04 PM", s)
+	if err != nil {
+		return s
+	}
+	return r.locale().Clock(t)
+}
+
+// labelWidth is the width that aligns the values after labels
+func labelWidth(labels ...string) int {
+	width := 0
+	for _, label := range labels {
+		width = max(width, utf8.RuneCountInString(label))
+	}
+	return width + 1
+}
+
+// placeholder stands in for a panel that has no data to show, translating
+// its message
+func (r *Renderer) placeholder(message string) {
+	r.paint(RoleMuted).Fprintf(r.w, "  %s\n", r.t(message))
+	fmt.Fprintln(r.w)
+}
+
+// OrPlaceholder returns s, or "--" when s is empty, for output that needs
+// a value in every field
+func OrPlaceholder(s string) string {
+	if s == "" {
+		return "--"
+	}
+	return s
+}
+
+// colorEnabled reports whether escape sequences may be written
+func (r *Renderer) colorEnabled() bool {
+	return !r.settings.NoColor && !color.NoColor
+}
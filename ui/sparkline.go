@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/biferdou/illapaca/model"
+	"golang.org/x/term"
+)
+
+// DefaultWidth is the number of columns assumed when the terminal's width
+// is unknown
+const DefaultWidth = 80
+
+// sparkBlocks are the levels of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// maxSparkWidth is the widest an inline sparkline gets: one column per
+// hour of a day
+const maxSparkWidth = 24
+
+// minSparkWidth is the narrowest inline sparkline worth drawing
+const minSparkWidth = 6
+
+// DetectWidth returns the number of columns from COLUMNS, or else the
+// width of the terminal on stdout, or 0 if neither is known
+func DetectWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	return 0
+}
+
+// width returns the number of columns output may use
+func (r *Renderer) width() int {
+	if r.settings.Width > 0 {
+		return r.settings.Width
+	}
+	return DefaultWidth
+}
+
+// sparkWidth divides the columns left after used between n sparklines,
+// from minSparkWidth to maxSparkWidth each, or returns 0 when too few are
+// left to draw them
+func (r *Renderer) sparkWidth(used, n int) int {
+	w := min((r.width()-used)/n, maxSparkWidth)
+	if w < minSparkWidth {
+		return 0
+	}
+	return w
+}
+
+// hourlyTemps returns the temperatures of hours
+func hourlyTemps(hours []model.Hour) []float64 {
+	values := make([]float64, len(hours))
+	for i, hour := range hours {
+		values[i] = hour.TempC
+	}
+	return values
+}
+
+// hourlyRain returns the chances of rain of hours
+func hourlyRain(hours []model.Hour) []float64 {
+	values := make([]float64, len(hours))
+	for i, hour := range hours {
+		values[i] = float64(hour.ChanceOfRain)
+	}
+	return values
+}
+
+// finite reports whether v is neither NaN nor infinite
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// sparkline draws values as one block character each, scaled between
+// their minimum and maximum. Values that are not finite are left blank.
+func sparkline(values []float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if finite(v) {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	return sparklineRange(values, lo, hi)
+}
+
+// sparklineRange draws values as one block character each, scaled between
+// lo and hi
+func sparklineRange(values []float64, lo, hi float64) string {
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case !finite(v):
+			b.WriteRune(' ')
+		case !(hi > lo):
+			b.WriteRune(sparkBlocks[len(sparkBlocks)/2])
+		default:
+			level := int(math.Round((min(max(v, lo), hi) - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+			b.WriteRune(sparkBlocks[level])
+		}
+	}
+	return b.String()
+}
+
+// resample averages values into width buckets when there are more values
+// than columns. A bucket with no finite values is NaN.
+func resample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+	out := make([]float64, width)
+	for i := range out {
+		sum, n := 0.0, 0
+		for _, v := range values[i*len(values)/width : (i+1)*len(values)/width] {
+			if finite(v) {
+				sum += v
+				n++
+			}
+		}
+		out[i] = math.NaN()
+		if n > 0 {
+			out[i] = sum / float64(n)
+		}
+	}
+	return out
+}
+
+// tempTrend draws hourly temperatures as a sparkline at most width
+// columns wide. In accessible mode, where block characters mean nothing,
+// it gives the range instead.
+func (r *Renderer) tempTrend(hours []model.Hour, width int) string {
+	values := hourlyTemps(hours)
+	if r.settings.Accessible {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, v := range values {
+			if finite(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+		if math.IsInf(lo, 1) {
+			return ""
+		}
+		return r.tf("%.0f°C to %.0f°C", lo, hi)
+	}
+	if width == 0 {
+		return ""
+	}
+	return r.paint(RoleTemp).Sprint(sparkline(resample(values, width)))
+}
+
+// rainTrend draws hourly chances of rain as a sparkline at most width
+// columns wide, on a fixed scale from 0% to 100%. In accessible mode it
+// gives the peak instead.
+func (r *Renderer) rainTrend(hours []model.Hour, width int) string {
+	values := hourlyRain(hours)
+	if r.settings.Accessible {
+		if len(values) == 0 {
+			return ""
+		}
+		peak := 0.0
+		for _, v := range values {
+			peak = math.Max(peak, v)
+		}
+		return r.tf("up to %.0f%%", peak)
+	}
+	if width == 0 {
+		return ""
+	}
+	return r.paint(RoleRain3).Sprint(sparklineRange(resample(values, width), 0, 100))
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// Funcs returns the helper functions available to user templates:
+//
+//	icon .Current              condition icon in the configured set; also
+//	                           accepts a condition, an hour or condition text
+//	temp 16.2                  temperature in °C, formatted in the preferred units
+//	speed 13.0                 wind speed in km/h, formatted in the preferred units
+//	c2f, f2c, kph2mph, mm2in   unit conversion
+//	round 16.25 1              round to a number of decimal places
+//	color "alert" "text"       color text in a theme role or a style such as
+//	                           "hi-red bold", unless color is disabled
+//	sparkline (hourlyTemps .)  one-line chart of a series
+//	bar 7 10 20                bar of value out of total, width characters wide
+//	hourlyTemps, hourlyRain    a forecast day's hourly temperatures or rain chances
+//	maxTemps, minTemps         each forecast day's high or low
+//	alerts .                   alert messages under the configured thresholds
+//	upper, lower, join         string helpers
+func (r *Renderer) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"icon": func(v interface{}) (string, error) {
+			switch c := v.(type) {
+			case model.Condition:
+				return r.icon(c, true), nil
+			case model.CurrentWeather:
+				return r.icon(c.Condition, c.IsDay == 1), nil
+			case model.Hour:
+				return r.icon(c.Condition, c.IsDay == 1), nil
+			case string:
+				return r.icon(model.Condition{Text: c}, true), nil
+			}
+			return "", fmt.Errorf("icon expects a condition, got %T", v)
+		},
+		"temp": func(c float64) string {
+			if r.settings.Units == "imperial" {
+				return fmt.Sprintf("%.1f°F", c*9/5+32)
+			}
+			return fmt.Sprintf("%.1f°C", c)
+		},
+		"speed":   r.speed,
+		"c2f":     func(c float64) float64 { return c*9/5 + 32 },
+		"f2c":     func(f float64) float64 { return (f - 32) * 5 / 9 },
+		"kph2mph": func(kph float64) float64 { return kph / 1.609344 },
+		"mm2in":   func(mm float64) float64 { return mm / 25.4 },
+		"round": func(x float64, places int) float64 {
+			scale := math.Pow(10, float64(places))
+			return math.Round(x*scale) / scale
+		},
+		"color": func(name, s string) (string, error) {
+			if style, ok := r.theme().styles[Role(name)]; ok {
+				return r.paintStyle(style).Sprint(s), nil
+			}
+			style, err := ParseStyle(name)
+			if err != nil {
+				return "", err
+			}
+			return r.paintStyle(style).Sprint(s), nil
+		},
+		"sparkline": sparkline,
+		"bar": func(value, total interface{}, width int) (string, error) {
+			v, err := toFloat(value)
+			if err != nil {
+				return "", err
+			}
+			t, err := toFloat(total)
+			if err != nil || t <= 0 || width <= 0 {
+				return "", err
+			}
+			ratio := v / t
+			if math.IsNaN(ratio) {
+				ratio = 0
+			}
+			n := int(math.Round(min(max(ratio, 0), 1) * float64(width)))
+			return strings.Repeat("█", n) + strings.Repeat("░", width-n), nil
+		},
+		"hourlyTemps": func(day model.ForecastDay) []float64 { return hourlyTemps(day.Hour) },
+		"hourlyRain":  func(day model.ForecastDay) []float64 { return hourlyRain(day.Hour) },
+		"maxTemps": func(data *model.WeatherData) []float64 {
+			values := make([]float64, len(data.Forecast.ForecastDay))
+			for i, day := range data.Forecast.ForecastDay {
+				values[i] = day.Day.MaxTempC
+			}
+			return values
+		},
+		"minTemps": func(data *model.WeatherData) []float64 {
+			values := make([]float64, len(data.Forecast.ForecastDay))
+			for i, day := range data.Forecast.ForecastDay {
+				values[i] = day.Day.MinTempC
+			}
+			return values
+		},
+		"alerts": func(data *model.WeatherData) []string {
+			return Alerts(data, r.settings.AlertThresholds)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join":  strings.Join,
+	}
+}
+
+// speed formats a wind speed in km/h in the preferred units
+func (r *Renderer) speed(kph float64) string {
+	if r.settings.Units == "imperial" {
+		return fmt.Sprintf("%.0f mph", kph/1.609344)
+	}
+	return fmt.Sprintf("%.0f km/h", kph)
+}
+
+// LoadTemplate parses a user template file with the helper functions. A
+// leading ~/ is expanded to the home directory.
+func (r *Renderer) LoadTemplate(path string) (*template.Template, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(filepath.Base(path)).Funcs(r.Funcs()).Parse(string(raw))
+}
+
+// DisplayTemplate renders data through a user template
+func (r *Renderer) DisplayTemplate(tmpl *template.Template, data *model.WeatherData) error {
+	return tmpl.Execute(r.w, data)
+}
+
+// toFloat accepts the integer and float fields of the model alike
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	}
+	return 0, fmt.Errorf("expected a number, got %T", v)
+}
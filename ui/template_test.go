@@ -0,0 +1,49 @@
+package ui_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"text/template"
+
+	"github.com/biferdou/illapaca/ui"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	imperial := testSettings
+	imperial.Units = "imperial"
+
+	tests := []struct {
+		settings ui.Settings
+		text     string
+		data     interface{}
+		want     string
+	}{
+		{testSettings, `{{temp 20}} {{speed 16.09344}}`, nil, "20.0°C 16 km/h"},
+		{imperial, `{{temp 20}} {{speed 16.09344}}`, nil, "68.0°F 10 mph"},
+		{testSettings, `{{round (f2c 50) 2}} {{round (mm2in 25.4) 1}}`, nil, "10 1"},
+		{testSettings, `{{color "red" "hot"}}`, nil, "hot"},
+		{testSettings, `{{sparkline .}}`, []float64{1, 2, 3, 4, 5, 6, 7, 8}, "▁▂▃▄▅▆▇█"},
+		{testSettings, `{{sparkline .}}`, []float64{3, math.NaN(), 3}, "▅ ▅"},
+		{testSettings, `{{bar 50 100 4}}|{{bar 7 0 4}}`, nil, "██░░|"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		r := ui.NewRenderer(&buf, tt.settings)
+		tmpl := template.Must(template.New("t").Funcs(r.Funcs()).Parse(tt.text))
+		if err := tmpl.Execute(&buf, tt.data); err != nil {
+			t.Fatalf("%s: %v", tt.text, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s = %q, want %q", tt.text, buf.String(), tt.want)
+		}
+	}
+
+	r := ui.NewRenderer(&bytes.Buffer{}, testSettings)
+	for _, text := range []string{`{{color "plaid" "x"}}`, `{{bar "x" 10 4}}`} {
+		tmpl := template.Must(template.New("t").Funcs(r.Funcs()).Parse(text))
+		if err := tmpl.Execute(&bytes.Buffer{}, nil); err == nil {
+			t.Errorf("%s did not fail", text)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Role names an element of the display that a theme colors
+type Role string
+
+// Roles themed by every palette
+const (
+	RoleTitle      Role = "title"       // report titles
+	RoleHeading    Role = "heading"     // locations and dashboard headings
+	RoleSubheading Role = "subheading"  // sections within a report
+	RoleChartTitle Role = "chart_title" // chart titles
+	RoleHeader     Role = "header"      // table headers
+	RoleLabel      Role = "label"       // labels of label/value lines
+	RoleValue      Role = "value"       // values of label/value lines
+	RoleText       Role = "text"        // conditions and notes
+	RoleMuted      Role = "muted"       // placeholders for missing data
+	RoleAccent     Role = "accent"      // analysis and summaries
+	RoleTemp       Role = "temp"        // temperatures in the main units
+	RoleTempAlt    Role = "temp_alt"    // temperatures in the other units
+	RoleAlert      Role = "alert"       // alert headings
+	RoleAlertText  Role = "alert_text"  // alert messages
+
+	// Temperature scale, coldest first
+	RoleCold Role = "cold"
+	RoleCool Role = "cool"
+	RoleMild Role = "mild"
+	RoleWarm Role = "warm"
+	RoleHot  Role = "hot"
+
+	// Rain chance scale, driest first
+	RoleRain1 Role = "rain_1"
+	RoleRain2 Role = "rain_2"
+	RoleRain3 Role = "rain_3"
+	RoleRain4 Role = "rain_4"
+	RoleRain5 Role = "rain_5"
+
+	// Severity scale, e.g. for the UV index
+	RoleOK      Role = "ok"
+	RoleCaution Role = "caution"
+	RoleWarning Role = "warning"
+	RoleDanger  Role = "danger"
+)
+
+// roles lists every Role, in the order themes are documented
+var roles = []Role{
+	RoleTitle, RoleHeading, RoleSubheading, RoleChartTitle, RoleHeader,
+	RoleLabel, RoleValue, RoleText, RoleMuted, RoleAccent,
+	RoleTemp, RoleTempAlt, RoleAlert, RoleAlertText,
+	RoleCold, RoleCool, RoleMild, RoleWarm, RoleHot,
+	RoleRain1, RoleRain2, RoleRain3, RoleRain4, RoleRain5,
+	RoleOK, RoleCaution, RoleWarning, RoleDanger,
+}
+
+// Roles returns every role a theme can set
+func Roles() []Role {
+	return append([]Role(nil), roles...)
+}
+
+// temperatureRoles and rainRoles are the scales, lowest first
+var (
+	temperatureRoles = []Role{RoleCold, RoleCool, RoleMild, RoleWarm, RoleHot}
+	rainRoles        = []Role{RoleRain1, RoleRain2, RoleRain3, RoleRain4, RoleRain5}
+)
+
+// ColorDepth is the number of colors a terminal can show
+type ColorDepth int
+
+// Color depths, from the most widely supported
+const (
+	Color16 ColorDepth = iota
+	Color256
+	ColorTrue
+)
+
+// DetectColorDepth guesses the terminal's color depth from COLORTERM and
+// TERM. Whether to use color at all is decided separately (see NoColor).
+func DetectColorDepth() ColorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorTrue
+	}
+	term := os.Getenv("TERM")
+	if strings.Contains(term, "truecolor") || strings.Contains(term, "direct") {
+		return ColorTrue
+	}
+	if strings.Contains(term, "256") {
+		return Color256
+	}
+	return Color16
+}
+
+// NoColorRequested reports whether the NO_COLOR convention (no-color.org)
+// asks for plain output
+func NoColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// colorKind tells how a Color was written
+type colorKind int
+
+const (
+	colorNone  colorKind = iota
+	colorANSI            // one of the 16 named colors
+	colorIndex           // a 256-color palette index
+	colorRGB             // a #rrggbb value
+)
+
+// Color is a terminal color given by name, palette index or RGB value
+type Color struct {
+	kind    colorKind
+	n       int // ANSI number (0-15) or palette index (0-255)
+	r, g, b uint8
+}
+
+// ansiNames are the 16 named colors, in ANSI order
+var ansiNames = []string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"hi-black", "hi-red", "hi-green", "hi-yellow", "hi-blue", "hi-magenta", "hi-cyan", "hi-white",
+}
+
+// ansiRGB are xterm's default values for the 16 named colors
+var ansiRGB = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 cube in the 256 palette
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// ParseColor reads a color name ("cyan", "hi-cyan", "gray"), a 256-color
+// palette index ("39") or an RGB value ("#268bd2")
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "gray" || s == "grey" {
+		s = "hi-black"
+	}
+	for i, name := range ansiNames {
+		if s == name {
+			return Color{kind: colorANSI, n: i}, nil
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > 255 {
+			return Color{}, fmt.Errorf("color index %d is not between 0 and 255", n)
+		}
+		return Color{kind: colorIndex, n: n}, nil
+	}
+	if hex, ok := strings.CutPrefix(s, "#"); ok && len(hex) == 6 {
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return Color{kind: colorRGB, r: uint8(v >> 16), g: uint8(v >> 8), b: uint8(v)}, nil
+		}
+	}
+	return Color{}, fmt.Errorf("unknown color %q (use a name such as hi-cyan, a 0-255 index or #rrggbb)", s)
+}
+
+// RGB returns the color's red, green and blue values
+func (c Color) RGB() (r, g, b uint8) {
+	switch c.kind {
+	case colorANSI:
+		v := ansiRGB[c.n]
+		return v[0], v[1], v[2]
+	case colorIndex:
+		return indexRGB(c.n)
+	}
+	return c.r, c.g, c.b
+}
+
+// Hex returns the color as #rrggbb
+func (c Color) Hex() string {
+	r, g, b := c.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// attrs returns the SGR parameters that select the color at depth
+func (c Color) attrs(depth ColorDepth) []color.Attribute {
+	switch c.kind {
+	case colorNone:
+		return nil
+	case colorANSI:
+		return []color.Attribute{ansiAttr(c.n)}
+	case colorIndex:
+		if depth >= Color256 {
+			return []color.Attribute{38, 5, color.Attribute(c.n)}
+		}
+	case colorRGB:
+		switch depth {
+		case ColorTrue:
+			return []color.Attribute{38, 2, color.Attribute(c.r), color.Attribute(c.g), color.Attribute(c.b)}
+		case Color256:
+			return []color.Attribute{38, 5, color.Attribute(nearest256(c.RGB()))}
+		}
+	}
+	return []color.Attribute{ansiAttr(nearest16(c.RGB()))}
+}
+
+// ansiAttr returns the foreground attribute of ANSI color n
+func ansiAttr(n int) color.Attribute {
+	if n < 8 {
+		return color.FgBlack + color.Attribute(n)
+	}
+	return color.FgHiBlack + color.Attribute(n-8)
+}
+
+// indexRGB returns the RGB value of a 256-color palette index
+func indexRGB(n int) (r, g, b uint8) {
+	switch {
+	case n < 16:
+		v := ansiRGB[n]
+		return v[0], v[1], v[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	default:
+		v := uint8(8 + 10*(n-232))
+		return v, v, v
+	}
+}
+
+// distance is the squared distance between two RGB values
+func distance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+// nearest16 returns the named color closest to an RGB value
+func nearest16(r, g, b uint8) int {
+	best, bestDist := 0, math.MaxInt
+	for i, v := range ansiRGB {
+		if d := distance(r, g, b, v[0], v[1], v[2]); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// nearest256 returns the palette index closest to an RGB value, from the
+// color cube or the gray ramp
+func nearest256(r, g, b uint8) int {
+	level := func(v uint8) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if math.Abs(float64(l)-float64(v)) < math.Abs(float64(cubeLevels[best])-float64(v)) {
+				best = i
+			}
+		}
+		return best
+	}
+	cube := 16 + 36*level(r) + 6*level(g) + level(b)
+
+	avg := (int(r) + int(g) + int(b)) / 3
+	gray := 232 + min(max((avg-3)/10, 0), 23)
+
+	cr, cg, cb := indexRGB(cube)
+	gr, gg, gb := indexRGB(gray)
+	if distance(r, g, b, gr, gg, gb) < distance(r, g, b, cr, cg, cb) {
+		return gray
+	}
+	return cube
+}
+
+// Style is how one role is drawn: a color, optionally bold
+type Style struct {
+	Color Color
+	Bold  bool
+}
+
+// ParseStyle reads a style such as "hi-cyan bold", "#268bd2" or "bold"
+func ParseStyle(spec string) (Style, error) {
+	var style Style
+	for _, field := range strings.Fields(spec) {
+		if strings.EqualFold(field, "bold") {
+			style.Bold = true
+			continue
+		}
+		if style.Color.kind != colorNone {
+			return Style{}, fmt.Errorf("style %q has more than one color", spec)
+		}
+		c, err := ParseColor(field)
+		if err != nil {
+			return Style{}, err
+		}
+		style.Color = c
+	}
+	return style, nil
+}
+
+// attrs returns the SGR parameters for the style at depth
+func (s Style) attrs(depth ColorDepth) []color.Attribute {
+	attrs := s.Color.attrs(depth)
+	if s.Bold {
+		attrs = append([]color.Attribute{color.Bold}, attrs...)
+	}
+	return attrs
+}
+
+// Theme assigns a Style to every Role
+type Theme struct {
+	Name   string
+	styles map[Role]Style
+}
+
+// Style returns the style of role
+func (t *Theme) Style(role Role) Style {
+	return t.styles[role]
+}
+
+// builtinThemes are the named palettes, as role to style specs
+var builtinThemes = map[string]map[Role]string{
+	"default": {
+		RoleTitle: "hi-magenta bold", RoleHeading: "hi-cyan bold", RoleSubheading: "hi-white bold",
+		RoleChartTitle: "hi-green bold", RoleHeader: "hi-blue bold",
+		RoleLabel: "hi-blue", RoleValue: "white", RoleText: "hi-white", RoleMuted: "hi-black",
+		RoleAccent: "hi-cyan", RoleTemp: "hi-yellow bold", RoleTempAlt: "yellow",
+		RoleAlert: "hi-red bold", RoleAlertText: "hi-red",
+		RoleCold: "hi-blue", RoleCool: "hi-cyan", RoleMild: "hi-green", RoleWarm: "hi-yellow", RoleHot: "hi-red",
+		RoleRain1: "39", RoleRain2: "45", RoleRain3: "51", RoleRain4: "33", RoleRain5: "27",
+		RoleOK: "hi-green", RoleCaution: "hi-yellow", RoleWarning: "hi-magenta", RoleDanger: "hi-red",
+	},
+	"high-contrast": {
+		RoleTitle: "hi-white bold", RoleHeading: "hi-yellow bold", RoleSubheading: "hi-white bold",
+		RoleChartTitle: "hi-white bold", RoleHeader: "hi-white bold",
+		RoleLabel: "hi-cyan bold", RoleValue: "hi-white", RoleText: "hi-white", RoleMuted: "white",
+		RoleAccent: "hi-yellow", RoleTemp: "hi-yellow bold", RoleTempAlt: "hi-white",
+		RoleAlert: "hi-red bold", RoleAlertText: "hi-red bold",
+		RoleCold: "hi-blue bold", RoleCool: "hi-cyan bold", RoleMild: "hi-white bold", RoleWarm: "hi-yellow bold", RoleHot: "hi-red bold",
+		RoleRain1: "hi-white", RoleRain2: "hi-cyan", RoleRain3: "hi-cyan bold", RoleRain4: "hi-blue bold", RoleRain5: "hi-magenta bold",
+		RoleOK: "hi-green bold", RoleCaution: "hi-yellow bold", RoleWarning: "hi-magenta bold", RoleDanger: "hi-red bold",
+	},
+	"solarized": {
+		RoleTitle: "#d33682 bold", RoleHeading: "#268bd2 bold", RoleSubheading: "#93a1a1 bold",
+		RoleChartTitle: "#859900 bold", RoleHeader: "#268bd2 bold",
+		RoleLabel: "#268bd2", RoleValue: "#839496", RoleText: "#93a1a1", RoleMuted: "#586e75",
+		RoleAccent: "#2aa198", RoleTemp: "#b58900 bold", RoleTempAlt: "#b58900",
+		RoleAlert: "#dc322f bold", RoleAlertText: "#dc322f",
+		RoleCold: "#268bd2", RoleCool: "#2aa198", RoleMild: "#859900", RoleWarm: "#b58900", RoleHot: "#dc322f",
+		RoleRain1: "#93a1a1", RoleRain2: "#2aa198", RoleRain3: "#268bd2", RoleRain4: "#6c71c4", RoleRain5: "#6c71c4 bold",
+		RoleOK: "#859900", RoleCaution: "#b58900", RoleWarning: "#cb4b16", RoleDanger: "#dc322f",
+	},
+	// Okabe-Ito colors, distinguishable with the common color vision
+	// deficiencies; no scale relies on telling red from green
+	"colorblind-safe": {
+		RoleTitle: "#cc79a7 bold", RoleHeading: "#56b4e9 bold", RoleSubheading: "hi-white bold",
+		RoleChartTitle: "#e69f00 bold", RoleHeader: "#56b4e9 bold",
+		RoleLabel: "#56b4e9", RoleValue: "white", RoleText: "hi-white", RoleMuted: "hi-black",
+		RoleAccent: "#56b4e9", RoleTemp: "#f0e442 bold", RoleTempAlt: "#f0e442",
+		RoleAlert: "#d55e00 bold", RoleAlertText: "#d55e00",
+		RoleCold: "#0072b2", RoleCool: "#56b4e9", RoleMild: "#f0e442", RoleWarm: "#e69f00", RoleHot: "#d55e00",
+		RoleRain1: "#c6dbef", RoleRain2: "#9ecae1", RoleRain3: "#6baed6", RoleRain4: "#3182bd", RoleRain5: "#08519c",
+		RoleOK: "#009e73", RoleCaution: "#f0e442", RoleWarning: "#e69f00", RoleDanger: "#d55e00",
+	},
+}
+
+// DefaultTheme is used when none is chosen
+const DefaultTheme = "default"
+
+// ThemeNames returns the built-in theme names, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme returns a built-in theme
+func LookupTheme(name string) (*Theme, error) {
+	specs, ok := builtinThemes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q (choose from %s, or define it under themes)",
+			name, strings.Join(ThemeNames(), ", "))
+	}
+	theme := &Theme{Name: name, styles: map[Role]Style{}}
+	for role, spec := range specs {
+		style, err := ParseStyle(spec)
+		if err != nil {
+			return nil, fmt.Errorf("theme %s: %s: %w", name, role, err)
+		}
+		theme.styles[role] = style
+	}
+	for _, role := range roles {
+		if _, ok := theme.styles[role]; !ok {
+			return nil, fmt.Errorf("theme %s does not style %s", name, role)
+		}
+	}
+	return theme, nil
+}
+
+// NewTheme derives a theme from base, replacing the styles of the roles
+// in overrides. Keys are role names and values style specs.
+func NewTheme(name string, base *Theme, overrides map[string]string) (*Theme, error) {
+	theme := &Theme{Name: name, styles: map[Role]Style{}}
+	for role, style := range base.styles {
+		theme.styles[role] = style
+	}
+	for key, spec := range overrides {
+		role := Role(strings.ToLower(key))
+		if _, ok := base.styles[role]; !ok {
+			return nil, fmt.Errorf("theme %s: unknown role %q", name, key)
+		}
+		style, err := ParseStyle(spec)
+		if err != nil {
+			return nil, fmt.Errorf("theme %s: %s: %w", name, key, err)
+		}
+		theme.styles[role] = style
+	}
+	return theme, nil
+}
+
+// defaultTheme is used by renderers created without a theme
+var defaultTheme, _ = LookupTheme(DefaultTheme)
+
+// theme returns the renderer's theme
+func (r *Renderer) theme() *Theme {
+	if r.settings.Theme != nil {
+		return r.settings.Theme
+	}
+	return defaultTheme
+}
+
+// paint returns the color for role in the renderer's theme and color
+// depth, honoring NoColor
+func (r *Renderer) paint(role Role) *color.Color {
+	return r.paintStyle(r.theme().Style(role))
+}
+
+// paintStyle returns the color for style at the renderer's color depth
+func (r *Renderer) paintStyle(style Style) *color.Color {
+	c := color.New(style.attrs(r.settings.ColorDepth)...)
+	if r.settings.NoColor {
+		c.DisableColor()
+	}
+	return c
+}
+
+// headerColors returns the tablewriter colors for a table header in role,
+// always bold
+func (r *Renderer) headerColors(role Role) tablewriter.Colors {
+	style := r.theme().Style(role)
+	style.Bold = true
+	var colors tablewriter.Colors
+	for _, attr := range style.attrs(r.settings.ColorDepth) {
+		colors = append(colors, int(attr))
+	}
+	return colors
+}
+
+// scaleRole picks the role for value on a scale of roles spread evenly
+// from lo to hi
+func scaleRole(scale []Role, value, lo, hi float64) Role {
+	return scale[scaleIndex(len(scale), value, lo, hi)]
+}
+
+// scaleIndex places value in one of n steps spread evenly from lo to hi.
+// A flat or invalid range gets the middle step.
+func scaleIndex(n int, value, lo, hi float64) int {
+	if !(hi > lo) {
+		return n / 2
+	}
+	i := int((value - lo) / (hi - lo) * float64(n))
+	return min(max(i, 0), n-1)
+}
@@ -0,0 +1,97 @@
+package ui_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/biferdou/illapaca/api"
+	"github.com/biferdou/illapaca/api/apitest"
+	"github.com/biferdou/illapaca/ui"
+	"github.com/fatih/color"
+)
+
+func TestThemes(t *testing.T) {
+	for _, name := range ui.ThemeNames() {
+		if _, err := ui.LookupTheme(name); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if _, err := ui.LookupTheme("neon"); err == nil {
+		t.Error("unknown theme was found")
+	}
+
+	base, _ := ui.LookupTheme(ui.DefaultTheme)
+	theme, err := ui.NewTheme("mine", base, map[string]string{"Title": "#ff8800 bold"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := theme.Style(ui.RoleTitle); got.Color.Hex() != "#ff8800" || !got.Bold {
+		t.Errorf("title = %s bold=%v", got.Color.Hex(), got.Bold)
+	}
+	if theme.Style(ui.RoleLabel) != base.Style(ui.RoleLabel) {
+		t.Error("roles not overridden should keep the base style")
+	}
+
+	for _, overrides := range []map[string]string{
+		{"sky": "blue"},
+		{"title": "ultraviolet"},
+		{"title": "red blue"},
+	} {
+		if _, err := ui.NewTheme("bad", base, overrides); err == nil {
+			t.Errorf("%v was accepted", overrides)
+		}
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	for spec, hex := range map[string]string{
+		"hi-cyan": "#00ffff",
+		"Gray":    "#7f7f7f",
+		"39":      "#00afff",
+		"244":     "#808080",
+		"#268BD2": "#268bd2",
+	} {
+		c, err := ui.ParseColor(spec)
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		if c.Hex() != hex {
+			t.Errorf("%s = %s, want %s", spec, c.Hex(), hex)
+		}
+	}
+	for _, spec := range []string{"256", "#12345", "#gggggg", "teal"} {
+		if _, err := ui.ParseColor(spec); err == nil {
+			t.Errorf("%s was accepted", spec)
+		}
+	}
+}
+
+func TestThemeColorDepth(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	data := fetch(t, api.ProviderWeatherAPI, apitest.LocationOK, 3)
+	solarized, _ := ui.LookupTheme("solarized")
+
+	// The forecast's first title is solarized magenta #d33682, bold
+	for depth, want := range map[ui.ColorDepth]string{
+		ui.ColorTrue: "\x1b[1;38;2;211;54;130m",
+		ui.Color256:  "\x1b[1;38;5;168m",
+		ui.Color16:   "\x1b[1;35m",
+	} {
+		var buf bytes.Buffer
+		ui.NewRenderer(&buf, ui.Settings{Theme: solarized, ColorDepth: depth}).DisplayForecast(data)
+		if !strings.HasPrefix(buf.String(), want+"Weather Forecast") {
+			t.Errorf("depth %d: output starts %q, want %q", depth, buf.String()[:20], want)
+		}
+	}
+
+	var buf bytes.Buffer
+	ui.NewRenderer(&buf, ui.Settings{Theme: solarized, ColorDepth: ui.ColorTrue, NoColor: true}).DisplayForecast(data)
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Error("NoColor output contains escape sequences")
+	}
+}
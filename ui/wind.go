@@ -0,0 +1,368 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/biferdou/illapaca/model"
+)
+
+// beaufortLimits are the lowest wind speeds in km/h of Beaufort forces 1
+// to 12
+var beaufortLimits = []float64{1, 6, 12, 20, 29, 39, 50, 62, 75, 89, 103, 118}
+
+// beaufortNames describe each Beaufort force
+var beaufortNames = []string{
+	"Calm", "Light air", "Light breeze", "Gentle breeze", "Moderate breeze",
+	"Fresh breeze", "Strong breeze", "Near gale", "Gale", "Strong gale",
+	"Storm", "Violent storm", "Hurricane force",
+}
+
+// compassPoints are the eight directions of the wind rose, clockwise from
+// north
+var compassPoints = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// windArrows point the way the wind blows for each of compassPoints: a
+// north wind blows south
+var windArrows = []string{"↓", "↙", "←", "↖", "↑", "↗", "→", "↘"}
+
+// roseRadius is the length in rows of the wind rose's longest arm
+const roseRadius = 4
+
+// barWidth is the width of the longest gust bar
+const barWidth = 40
+
+// Beaufort returns the Beaufort force, 0 to 12, of a wind speed in km/h
+func Beaufort(kph float64) int {
+	force := 0
+	for force < len(beaufortLimits) && kph >= beaufortLimits[force] {
+		force++
+	}
+	return force
+}
+
+// beaufortRole colors a wind speed by its Beaufort force
+func beaufortRole(kph float64) Role {
+	switch force := Beaufort(kph); {
+	case force >= 8:
+		return RoleDanger
+	case force >= 6:
+		return RoleWarning
+	case force >= 4:
+		return RoleCaution
+	default:
+		return RoleOK
+	}
+}
+
+// compassPoint returns the index in compassPoints nearest to a direction
+// in degrees
+func compassPoint(degree int) int {
+	degree = (degree%360 + 360) % 360
+	return int(math.Round(float64(degree)/45)) % len(compassPoints)
+}
+
+// upcomingHours returns up to n forecast hours from the location's local
+// time onwards, running into the following days. Without a usable local
+// time it starts at the first hour.
+func upcomingHours(data *model.WeatherData, n int) []model.Hour {
+	now, err := time.Parse("2006-01-02 15:04", data.Location.Localtime)
+	now = now.Truncate(time.Hour)
+	var hours []model.Hour
+	for _, day := range data.Forecast.ForecastDay {
+		for _, hour := range day.Hour {
+			if len(hours) == n {
+				return hours
+			}
+			t, parseErr := time.Parse("2006-01-02 15:04", hour.Time)
+			if err == nil && parseErr == nil && t.Before(now) {
+				continue
+			}
+			hours = append(hours, hour)
+		}
+	}
+	return hours
+}
+
+// DisplayWind outputs a wind panel: the current wind on a compass with
+// its Beaufort force, sustained wind and gusts over the next 24 hours, and
+// a wind rose of the day's hourly directions
+func (r *Renderer) DisplayWind(data *model.WeatherData) {
+	title := r.paint(RoleTitle)
+	title.Fprintln(r.w, r.tf("Wind: %s, %s", data.Location.Name, data.Location.Country))
+	fmt.Fprintln(r.w)
+
+	r.displayWindCompass(data.Current)
+	r.displayGustBars(upcomingHours(data, 24))
+	if len(data.Forecast.ForecastDay) > 0 {
+		r.displayWindRose(data.Forecast.ForecastDay[0])
+	}
+}
+
+// displayWindCompass draws an arrow on a compass for the current wind,
+// beside its speed, gusts and Beaufort force
+func (r *Renderer) displayWindCompass(current model.CurrentWeather) {
+	force := Beaufort(current.WindKph)
+	var lines []string
+	if force > 0 {
+		lines = append(lines, r.tf("%.1f km/h from the %s (%d°)",
+			current.WindKph, r.compass(current.WindDir), current.WindDegree))
+	}
+	if current.GustKph > 0 {
+		lines = append(lines, r.tf("Gusts up to %.1f km/h", current.GustKph))
+	}
+	lines = append(lines, r.tf("Beaufort %d: %s", force, r.t(beaufortNames[force])))
+
+	valueStyle := r.paint(RoleValue)
+	if r.settings.Accessible {
+		for _, line := range lines {
+			valueStyle.Fprintln(r.w, line)
+		}
+		fmt.Fprintln(r.w)
+		return
+	}
+
+	arrow := " "
+	if force > 0 {
+		arrow = r.paint(beaufortRole(current.WindKph)).Sprint(windArrows[compassPoint(current.WindDegree)])
+	}
+	n, e, s, w := r.compass("N"), r.compass("E"), r.compass("S"), r.compass("W")
+	compass := []string{
+		"       " + n + "     ",
+		"    ╭─────╮  ",
+		"  " + w + " │  " + arrow + "  │ " + e,
+		"    ╰─────╯  ",
+		"       " + s + "     ",
+	}
+	// The text starts beside the compass's second row
+	for i, line := range compass {
+		if i == 0 || i > len(lines) {
+			fmt.Fprintln(r.w, strings.TrimRight(line, " "))
+			continue
+		}
+		fmt.Fprint(r.w, line+"   ")
+		valueStyle.Fprintln(r.w, lines[i-1])
+	}
+	fmt.Fprintln(r.w)
+}
+
+// displayGustBars draws sustained wind and gusts every three hours as
+// horizontal bars on a common scale
+func (r *Renderer) displayGustBars(hours []model.Hour) {
+	chartTitle := r.paint(RoleChartTitle)
+	chartTitle.Fprintln(r.w, r.t("Sustained Wind and Gusts (next 24 hours)"))
+	fmt.Fprintln(r.w)
+
+	if len(hours) == 0 {
+		r.placeholder("No hourly wind data available")
+		return
+	}
+	if r.settings.Accessible {
+		r.displaySummary(r.describeWind(hours), "No hourly wind data available")
+		return
+	}
+
+	var steps []model.Hour
+	top := 0.0
+	for i := 0; i < len(hours); i += 3 {
+		steps = append(steps, hours[i])
+		for _, v := range []float64{hours[i].WindKph, hours[i].GustKph} {
+			if !math.IsNaN(v) {
+				top = math.Max(top, v)
+			}
+		}
+	}
+	if top == 0 {
+		top = 1
+	}
+
+	muted := r.paint(RoleMuted)
+	for _, hour := range steps {
+		sustained := barCells(hour.WindKph, top)
+		gust := max(sustained, barCells(hour.GustKph, top))
+		fmt.Fprintf(r.w, "  %s  ", hourLabel(hour.Time))
+		r.paint(beaufortRole(hour.WindKph)).Fprint(r.w, repeatChar("█", sustained))
+		r.paint(beaufortRole(hour.GustKph)).Fprint(r.w, repeatChar("░", gust-sustained))
+		fmt.Fprint(r.w, repeatChar(" ", barWidth-gust))
+		fmt.Fprintf(r.w, "  %-2s %s\n", r.compass(compassPoints[compassPoint(hour.WindDegree)]),
+			r.tf("%.0f / %.0f km/h", hour.WindKph, hour.GustKph))
+	}
+	fmt.Fprintln(r.w)
+	muted.Fprintf(r.w, "  █ %s  ░ %s\n", r.t("sustained"), r.t("gusts"))
+	fmt.Fprintln(r.w)
+}
+
+// barCells scales v against top to a bar length in [0, barWidth]. Negative
+// and missing values draw nothing.
+func barCells(v, top float64) int {
+	switch {
+	case math.IsNaN(v) || v <= 0:
+		return 0
+	case v >= top:
+		return barWidth
+	}
+	return clamp(int(math.Round(v/top*barWidth)), 0, barWidth)
+}
+
+// describeWind summarizes hourly wind in a sentence naming the strongest
+// sustained wind and gust
+func (r *Renderer) describeWind(hours []model.Hour) string {
+	wind, gust := 0, 0
+	for i, hour := range hours {
+		if hour.WindKph > hours[wind].WindKph {
+			wind = i
+		}
+		if hour.GustKph > hours[gust].GustKph {
+			gust = i
+		}
+	}
+	summary := r.tf("Sustained wind peaks at %.0f km/h at %s", hours[wind].WindKph, hourLabel(hours[wind].Time))
+	if hours[gust].GustKph > 0 {
+		summary += ", " + r.tf("with gusts up to %.0f km/h at %s", hours[gust].GustKph, hourLabel(hours[gust].Time))
+	}
+	return summary + "."
+}
+
+// windRose counts a day's hours by the direction the wind comes from,
+// leaving out calm hours
+func windRose(day model.ForecastDay) (counts []int, calm int) {
+	counts = make([]int, len(compassPoints))
+	for _, hour := range day.Hour {
+		if Beaufort(hour.WindKph) == 0 {
+			calm++
+			continue
+		}
+		counts[compassPoint(hour.WindDegree)]++
+	}
+	return counts, calm
+}
+
+// displayWindRose draws the share of the day's hours the wind comes from
+// each direction as arms of a compass rose
+func (r *Renderer) displayWindRose(day model.ForecastDay) {
+	chartTitle := r.paint(RoleChartTitle)
+	chartTitle.Fprintln(r.w, r.tf("Wind Rose (%s)", r.date(day.Date)))
+	fmt.Fprintln(r.w)
+
+	counts, calm := windRose(day)
+	total, top := 0, 0
+	for i, count := range counts {
+		total += count
+		if count > counts[top] {
+			top = i
+		}
+	}
+	if total == 0 {
+		if calm > 0 {
+			r.displaySummary(r.t("Calm all day."), "")
+		} else {
+			r.placeholder("No hourly wind data available")
+		}
+		return
+	}
+
+	share := func(i int) int {
+		return int(math.Round(float64(counts[i]) * 100 / float64(total)))
+	}
+	labels := make([]string, len(compassPoints))
+	for i, point := range compassPoints {
+		labels[i] = fmt.Sprintf("%s %d%%", r.compass(point), share(i))
+	}
+
+	if r.settings.Accessible {
+		var fields []string
+		for i := range compassPoints {
+			if counts[i] > 0 {
+				fields = append(fields, labels[i])
+			}
+		}
+		r.displaySummary(r.tf("Wind by direction: %s.", strings.Join(fields, ", ")), "")
+	} else {
+		r.drawWindRose(counts, counts[top], labels)
+	}
+
+	accent := r.paint(RoleAccent)
+	accent.Fprintln(r.w, r.tf("Mostly from the %s (%d%% of hours)", r.compass(compassPoints[top]), share(top)))
+	if calm > 0 {
+		accent.Fprintln(r.w, r.tf("Calm for %d hours", calm))
+	}
+	fmt.Fprintln(r.w)
+}
+
+// drawWindRose draws one arm per direction, as long as its count relative
+// to the largest, with each direction's label at the end of its arm
+func (r *Renderer) drawWindRose(counts []int, most int, labels []string) {
+	// Columns are twice as dense as rows, so arms step two columns per row
+	// and labels get a margin on either side
+	margin := 0
+	for _, label := range labels {
+		margin = max(margin, len([]rune(label))+2)
+	}
+	height := 2*roseRadius + 3
+	width := 2*margin + 4*roseRadius + 1
+	centerRow, centerCol := roseRadius+1, margin+2*roseRadius
+
+	grid := make([][]string, height)
+	for row := range grid {
+		grid[row] = make([]string, width)
+		for col := range grid[row] {
+			grid[row][col] = " "
+		}
+	}
+	put := func(row, col int, s string) {
+		if row >= 0 && row < height && col >= 0 && col < width {
+			grid[row][col] = s
+		}
+	}
+	// write places a label, right-aligned to col if it extends left
+	write := func(row, col int, label string, left bool) {
+		runes := []rune(label)
+		if left {
+			col -= len(runes) - 1
+		}
+		for i, c := range runes {
+			put(row, col+i, string(c))
+		}
+	}
+
+	steps := []struct{ dRow, dCol int }{
+		{-1, 0}, {-1, 2}, {0, 2}, {1, 2}, {1, 0}, {1, -2}, {0, -2}, {-1, -2},
+	}
+	strokes := []string{"│", "╱", "─", "╲", "│", "╱", "─", "╲"}
+
+	arm := r.paint(RoleAccent)
+	for i, step := range steps {
+		length := 0
+		if counts[i] > 0 {
+			length = max(1, int(math.Round(float64(counts[i])*roseRadius/float64(most))))
+		}
+		stroke := arm.Sprint(strokes[i])
+		for k := 1; k <= length; k++ {
+			row, col := centerRow+step.dRow*k, centerCol+step.dCol*k
+			put(row, col, stroke)
+			if step.dRow == 0 {
+				// Close the gap in horizontal arms
+				put(row, col-step.dCol/2, stroke)
+			}
+		}
+
+		// Labels sit just past the full-length arm
+		row, col := centerRow+step.dRow*(roseRadius+1), centerCol+step.dCol*(roseRadius+1)
+		switch {
+		case step.dCol == 0:
+			write(row, col-len([]rune(labels[i]))/2, labels[i], false)
+		case step.dCol < 0:
+			write(row, col, labels[i], true)
+		default:
+			write(row, col, labels[i], false)
+		}
+	}
+	put(centerRow, centerCol, "●")
+
+	for _, row := range grid {
+		fmt.Fprintln(r.w, strings.TrimRight("  "+strings.Join(row, ""), " "))
+	}
+	fmt.Fprintln(r.w)
+}
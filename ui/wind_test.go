@@ -0,0 +1,31 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/biferdou/illapaca/ui"
+)
+
+func TestBeaufort(t *testing.T) {
+	tests := []struct {
+		kph  float64
+		want int
+	}{
+		{0, 0},
+		{0.9, 0},
+		{1, 1},
+		{13, 3},
+		{19.9, 3},
+		{20, 4},
+		{61.9, 7},
+		{62, 8},
+		{117, 11},
+		{118, 12},
+		{250, 12},
+	}
+	for _, tt := range tests {
+		if got := ui.Beaufort(tt.kph); got != tt.want {
+			t.Errorf("Beaufort(%v) = %d, want %d", tt.kph, got, tt.want)
+		}
+	}
+}